@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseCollectionRule parses a rule on a slice field. Rules on a field of
+// struct elements skip nil pointer elements.
+func parseCollectionRule(stct StructInfo, f FieldInfo, rawRule string) (SchemaRule, error) {
+	slice := f.typ().Underlying().(*types.Slice)
+	elem, ptr := slice.Elem(), false
+	if p, ok := elem.Underlying().(*types.Pointer); ok {
+		elem, ptr = p.Elem(), true
+	}
+	x := "u." + f.Name
+	name, value, _ := strings.Cut(rawRule, "=")
+	rule := SchemaRule{
+		Name:   name,
+		Type:   ruleCollection,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: value},
+	}
+	fail := func(format string, args ...any) (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: %s", f.Pos, f.Name, name, fmt.Sprintf(format, args...))
+	}
+	// elemField returns the body of a function returning the field of
+	// element i converted by conv, or zero for nil elements, and the type
+	// of the field.
+	elemField := func(field, conv, zero string) (string, types.Type, error) {
+		obj, _, _ := types.LookupFieldOrMethod(elem, false, stct.Pkg, field)
+		v, ok := obj.(*types.Var)
+		if _, isStruct := elem.Underlying().(*types.Struct); !isStruct || !ok || !v.IsField() {
+			return "", nil, fmt.Errorf("element type %s has no field %s", slice.Elem(), field)
+		}
+		body := fmt.Sprintf("return %s(%s[i].%s)", conv, x, field)
+		if ptr {
+			body = fmt.Sprintf("if %s[i] == nil { return %s }; %s", x, zero, body)
+		}
+		return body, v.Type(), nil
+	}
+
+	switch {
+	case name == "distinct":
+		if value != "" {
+			return fail("takes no value")
+		}
+		if !types.Comparable(slice.Elem()) {
+			return fail("cannot compare elements of type %s", slice.Elem())
+		}
+		rule.Expr = fmt.Sprintf("_Gov_Duplicate(len(%s), func(i int) any { return %s[i] })", x, x)
+	case name == "unique":
+		body, typ, err := elemField(value, "any", "nil")
+		if err != nil {
+			return fail("%s", err)
+		}
+		if !types.Comparable(typ) {
+			return fail("cannot compare field %s of type %s", value, typ)
+		}
+		rule.Field2 = value
+		rule.Expr = fmt.Sprintf("_Gov_Duplicate(len(%s), func(i int) any { %s })", x, body)
+	case name == "contains":
+		basic, ok := slice.Elem().Underlying().(*types.Basic)
+		if !ok || basic.Info()&(types.IsNumeric|types.IsString|types.IsBoolean) == 0 {
+			return fail("cannot check elements of type %s", slice.Elem())
+		}
+		lit := value
+		if basic.Info()&types.IsString != 0 {
+			lit = strconv.Quote(value)
+		}
+		// Type check the conversion, e.g. int8(300) overflows.
+		if _, err := types.Eval(token.NewFileSet(), nil, token.NoPos, fmt.Sprintf("%s(%s)", basic.Name(), lit)); err != nil {
+			return fail("invalid value %s: %s", value, err)
+		}
+		rule.Expr = fmt.Sprintf("slices.Contains(%s, %s)", x, lit)
+	case strings.HasPrefix(name, "sum(") && value != "":
+		field, op, ok := strings.Cut(strings.TrimPrefix(name, "sum("), ")")
+		if !ok || op != "" && op != "<" && op != ">" {
+			return fail("invalid format, expected sum(Field)=value")
+		}
+		obj, _, _ := types.LookupFieldOrMethod(elem, false, stct.Pkg, field)
+		basic, ok := typeOf(obj).Underlying().(*types.Basic)
+		if !ok || basic.Info()&(types.IsInteger|types.IsFloat) == 0 {
+			return fail("cannot sum field %s of element type %s", field, slice.Elem())
+		}
+		c, err := parseNumber(value)
+		if err != nil {
+			return fail("%s", err)
+		}
+		sum := (&Value{Type: basic.Kind()}).TypeName()
+		lit := c.RatString()
+		if sum == "float64" {
+			v, _ := c.Float64()
+			lit = formatFloat(v)
+		} else if !c.IsInt() {
+			return fail("needs an integer to sum field %s of type %s, got %s", field, basic, value)
+		}
+		body, _, err := elemField(field, sum, "0")
+		if err != nil {
+			return fail("%s", err)
+		}
+		total := fmt.Sprintf("_Gov_Sum(len(%s), func(i int) %s { %s })", x, sum, body)
+		switch op {
+		case "<":
+			rule.Name, rule.Expr = "sum_max", fmt.Sprintf("%s <= %s", total, lit)
+		case ">":
+			rule.Name, rule.Expr = "sum_min", fmt.Sprintf("%s >= %s", total, lit)
+		default:
+			rule.Name, rule.Expr = "sum", fmt.Sprintf("%s == %s", total, lit)
+			if sum == "float64" {
+				// Allow for the rounding of decimal values, e.g. 33.3+33.3+33.4.
+				v, _ := c.Float64()
+				rule.Expr = fmt.Sprintf("math.Abs(%s-%s) <= %s", total, lit, formatFloat(max(1, math.Abs(v))*1e-9))
+				rule.Imports = []string{"math"}
+			}
+		}
+		rule.Field2 = field
+	default:
+		return fail("invalid rule, expected one of %s", strings.Join(collectionRules, ", "))
+	}
+	return rule, nil
+}
+
+// typeOf returns the type of obj, or the invalid type if obj is nil.
+func typeOf(obj types.Object) types.Type {
+	if obj == nil {
+		return types.Typ[types.Invalid]
+	}
+	return obj.Type()
+}
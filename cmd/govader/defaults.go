@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+// parseDefault parses the default value of a field of basic type.
+func parseDefault(f FieldInfo, value string) (*Value, error) {
+	basic, ok := f.typ().Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsNumeric|types.IsString|types.IsBoolean) == 0 || basic.Info()&types.IsComplex != 0 {
+		return nil, fmt.Errorf("%s: field %s: default needs a field of basic type, got %s", f.Pos, f.Name, f.typ())
+	}
+	def, err := parseBasicValue(basic, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: field %s: invalid default %q for type %s", f.Pos, f.Name, value, f.typ())
+	}
+	if x, ok := def.Value.(float64); ok && (math.IsInf(x, 0) || math.IsNaN(x)) {
+		return nil, fmt.Errorf("%s: field %s: default %q is not a finite number", f.Pos, f.Name, value)
+	}
+	return def, nil
+}
+
+// sizeOf returns the size in bytes of a basic numeric type.
+func sizeOf(basic *types.Basic) int {
+	return int(types.SizesFor("gc", "amd64").Sizeof(basic))
+}
+
+// checkDefault checks that the default value of a field satisfies the rules
+// of the field which can be decided without the other fields or services,
+// rules the generator cannot decide, e.g. max_graphemes, are left to runtime.
+func checkDefault(f FieldInfo, def *Value, rules []SchemaRule) error {
+	for _, rule := range rules {
+		if rule.Type == ruleCustom || rule.Type == ruleService || rule.Type == ruleExpr ||
+			rule.Type == ruleCompare || rule.Type == ruleConditional {
+			continue // Needs the function, a service or other fields.
+		}
+		if ok, known := satisfies(def, rule); known && !ok {
+			return fmt.Errorf("%s: field %s: default %v does not satisfy rule %s", f.Pos, f.Name, def.Value, rule.Name)
+		}
+	}
+	return nil
+}
+
+// satisfies reports whether v satisfies the rule, known is false if the
+// rule cannot be decided by the generator, e.g. min_graphemes.
+func satisfies(v *Value, rule SchemaRule) (ok, known bool) {
+	if rule.Type == ruleNumeric {
+		return satisfiesNumber(v, rule), true
+	}
+	s := fmt.Sprint(v.Value)
+	switch rule.Name {
+	case "required":
+		return s != "" && s != "0" && s != "false", true
+	case "prohibited":
+		return s == "" || s == "0" || s == "false", true
+	case "in", "enum", "not_in":
+		in := false
+		for _, arg := range rule.Args {
+			in = in || arg.Value == v.Value
+		}
+		return in == (rule.Name != "not_in"), true
+	case "regexp":
+		re, err := regexp.Compile(fmt.Sprint(rule.Cond1.Value))
+		return err == nil && re.MatchString(s), true
+	case "min", "max", "size", "between":
+		if v.Type == types.String {
+			return compareLength(utf8.RuneCountInString(s), rule)
+		}
+		if rule.Name == "size" {
+			return compareLength(len(s), rule)
+		}
+		x, err := parseNumber(s)
+		if err != nil {
+			return false, true // NaN and infinities fail the comparisons.
+		}
+		lo := mustParseNumber(fmt.Sprint(rule.Cond1.Value))
+		switch rule.Name {
+		case "min":
+			return x.Cmp(lo) >= 0, true
+		case "max":
+			return x.Cmp(lo) <= 0, true
+		}
+		hi := mustParseNumber(fmt.Sprint(rule.Cond2.Value))
+		return x.Cmp(lo) >= 0 && x.Cmp(hi) <= 0, true
+	case "min_bytes", "max_bytes":
+		return compareLength(len(s), SchemaRule{Name: strings.TrimSuffix(rule.Name, "_bytes"), Cond1: rule.Cond1})
+	case "starts_with":
+		return strings.HasPrefix(s, fmt.Sprint(rule.Cond1.Value)), true
+	case "ends_with":
+		return strings.HasSuffix(s, fmt.Sprint(rule.Cond1.Value)), true
+	case "contains":
+		return strings.Contains(s, fmt.Sprint(rule.Cond1.Value)), true
+	case "excludes":
+		return !strings.Contains(s, fmt.Sprint(rule.Cond1.Value)), true
+	case "email":
+		cond, _ := rule.Cond1.Value.(string)
+		opts := strings.Split(cond, ",")
+		domain, ok := govader.Email(s, opts)
+		return ok && govader.EmailDomain(domain, opts), true
+	case "uuid":
+		version, _ := rule.Cond1.Value.(string)
+		return govader.UUID(s, version), true
+	}
+	if check, ok := formatChecks[rule.Name]; ok {
+		return check(s), true
+	}
+	if check, ok := identifierChecks[rule.Name]; ok {
+		return check(s), true
+	}
+	return false, false
+}
+
+// identifierChecks maps the identifier rules to the runtime function
+// generated by GenIdentifierRule.
+var identifierChecks = map[string]func(string) bool{
+	"luhn":           govader.Luhn,
+	"iban":           govader.IBAN,
+	"isbn10":         govader.ISBN10,
+	"isbn13":         govader.ISBN13,
+	"e164":           govader.E164,
+	"iso3166_alpha2": govader.ISO3166Alpha2,
+	"iso3166_alpha3": govader.ISO3166Alpha3,
+	"iso4217":        govader.ISO4217,
+	"bcp47":          govader.BCP47,
+}
+
+// formatChecks maps the format, network and character class rules to the
+// runtime function generated by GenFormatRule.
+var formatChecks = map[string]func(string) bool{
+	"url":           govader.URL,
+	"http_url":      govader.HTTPURL,
+	"ulid":          govader.ULID,
+	"ip":            govader.IP,
+	"ipv4":          govader.IPv4,
+	"ipv6":          govader.IPv6,
+	"cidr":          govader.CIDR,
+	"mac":           govader.MAC,
+	"port":          govader.Port,
+	"hostname":      govader.Hostname,
+	"fqdn":          govader.FQDN,
+	"alpha":         govader.Alpha,
+	"alpha_num":     govader.AlphaNum,
+	"alpha_dash":    govader.AlphaDash,
+	"numeric":       govader.Numeric,
+	"ascii":         govader.ASCII,
+	"printable":     govader.Printable,
+	"lowercase":     govader.Lowercase,
+	"uppercase":     govader.Uppercase,
+	"no_whitespace": govader.NoWhitespace,
+}
+
+// satisfiesNumber reports whether the number v satisfies a numeric rule,
+// mirroring the expressions built by parseNumericRule.
+func satisfiesNumber(v *Value, rule SchemaRule) bool {
+	x, err := parseNumber(fmt.Sprint(v.Value))
+	if err != nil {
+		return false // NaN and infinities fail the comparisons.
+	}
+	c, _ := rule.Cond1.Value.(string)
+	switch rule.Name {
+	case "positive":
+		return x.Sign() > 0
+	case "negative":
+		return x.Sign() < 0
+	case "non_zero":
+		return x.Sign() != 0
+	case "gt":
+		return x.Cmp(mustParseNumber(c)) > 0
+	case "gte":
+		return x.Cmp(mustParseNumber(c)) >= 0
+	case "lt":
+		return x.Cmp(mustParseNumber(c)) < 0
+	case "lte":
+		return x.Cmp(mustParseNumber(c)) <= 0
+	case "multiple_of":
+		k := mustParseNumber(c)
+		if v.Type == types.Float32 || v.Type == types.Float64 {
+			xf, _ := x.Float64()
+			kf, _ := k.Float64()
+			kf = math.Abs(kf)
+			return math.Abs(math.Remainder(xf, kf)) <= kf*1e-9
+		}
+		return new(big.Rat).Quo(x, k).IsInt()
+	case "digits", "digits_between":
+		n := len(new(big.Int).Abs(x.Num()).String())
+		lo, _ := strconv.Atoi(c)
+		if rule.Name == "digits" {
+			return n == lo
+		}
+		hi, _ := strconv.Atoi(rule.Cond2.Value.(string))
+		return n >= lo && n <= hi
+	case "decimal_places":
+		bits := 64
+		if v.Type == types.Float32 {
+			bits = 32
+		}
+		_, frac, _ := strings.Cut(strconv.FormatFloat(v.Value.(float64), 'f', -1, bits), ".")
+		n, _ := strconv.Atoi(c)
+		return len(frac) <= n
+	}
+	return false
+}
+
+// compareLength compares the length n with the limits of a min, max, size
+// or between rule.
+func compareLength(n int, rule SchemaRule) (ok, known bool) {
+	lo, _ := strconv.Atoi(fmt.Sprint(rule.Cond1.Value))
+	switch rule.Name {
+	case "min":
+		return n >= lo, true
+	case "max":
+		return n <= lo, true
+	case "size":
+		return n == lo, true
+	}
+	hi, _ := strconv.Atoi(fmt.Sprint(rule.Cond2.Value))
+	return n >= lo && n <= hi, true
+}
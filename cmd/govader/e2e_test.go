@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -51,6 +52,55 @@ func TestEndToEnd(t *testing.T) {
 	}
 }
 
+// localeSource is a program checking that the placeholders of messages are
+// substituted, whatever the locale.
+const localeSource = `package main
+
+import "strings"
+
+type Locale struct {
+	Name     string ` + "`gov:\"min=3;in=abc,def\"`" + `
+	Password string ` + "`gov:\"password=min:12\"`" + `
+}
+
+func main() {
+	messages := NewLocaleSchema(Locale{Name: "x", Password: "x"}).Validate()
+	for i, value := range []string{"3", "def", "12"} {
+		if !strings.Contains(messages[i], value) || strings.Contains(messages[i], ":value") || strings.Contains(messages[i], ":field") {
+			panic("placeholder not substituted: " + messages[i])
+		}
+	}
+}
+`
+
+func TestLocales(t *testing.T) {
+	govader := govaderPath(t)
+	data, err := localeFS.ReadFile("locale.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var locales map[string]map[string]string
+	if err := json.Unmarshal(data, &locales); err != nil {
+		t.Fatal(err)
+	}
+	for locale := range locales {
+		t.Run(locale, func(t *testing.T) {
+			dir := t.TempDir()
+			source := filepath.Join(dir, "locale.go")
+			if err := os.WriteFile(source, []byte(localeSource), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			schemaSource := filepath.Join(dir, "Locale_schema.go")
+			if err := run(t, govader, "-type", "Locale", "-locale", locale, "-output", schemaSource, source); err != nil {
+				t.Fatal(err)
+			}
+			if err := run(t, "go", "run", schemaSource, source); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 // a type name for govader. use the last component of the file name with the .go
 func typeName(fname string) string {
 	// file names are known to be ascii and end .go
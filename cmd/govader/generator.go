@@ -2,10 +2,15 @@ package main
 
 import (
 	"fmt"
+	"go/types"
 	"io"
 	"slices"
+	"strings"
 )
 
+// runtimePkg is the import path of the govader runtime package.
+const runtimePkg = "github.com/ahmadwaleed/go-validation/govader"
+
 type Generator struct {
 	w              io.Writer // Accumulated output.
 	Schemas        []Schema
@@ -22,12 +27,12 @@ func (g *Generator) Generate() {
 	// Generator schema locale messages.
 	g.Printf("var _Gov_Schema_message = map[string]string{\n")
 	for rule, msg := range g.Messages {
-		g.Printf("\t\"%s\": \"%s\",\n", rule, msg)
+		g.Printf("\t%q: %q,\n", rule, msg)
 	}
 	g.Printf("}\n")
 
 	// Generate error func to return rule error messages.
-	g.AddImport("errors", "strings")
+	g.AddImport("context", "errors", "fmt", "reflect", "slices", "strings", "unicode", "unicode/utf8", runtimePkg)
 	g.Printf(`func _Gov_Error(key, field1, value1, field2, value2 string) error {
 		var msg string
 		for _, word := range strings.Split(_Gov_Schema_message[key], " ") {
@@ -35,20 +40,26 @@ func (g *Generator) Generate() {
 				msg += word + " "
 				continue
 			}
-			switch strings.Trim(word, ".") /* Remove trailing '.' */ {
+			// Keep the punctuation following a placeholder, e.g. ":value." or ":value۔".
+			name := strings.TrimRightFunc(word, unicode.IsPunct)
+			var value string
+			switch name {
 			case ":field", ":field1":
-				msg += field1 + " "
-			case ":value", ":value1":
-				msg += value1 + " "
+				value = field1
+			case ":value", ":value1", ":min":
+				value = value1
 			case ":field2":
-				msg += field2 + " "
-			case ":value2":
-				msg += value2 + " "
-			default:
+				value = field2
+			case ":value2", ":max":
+				value = value2
+			}
+			if value == "" {
+				msg = strings.TrimSuffix(msg, " ") // Punctuation goes to the previous word.
 			}
+			msg += value + word[len(name):] + " "
 		}
 		msg = strings.Trim(msg, " ")
-		if !strings.HasSuffix(msg, ".") {
+		if r, _ := utf8.DecodeLastRuneInString(msg); !unicode.Is(unicode.Sentence_Terminal, r) {
 			msg = msg + "."
 		}
 		return errors.New(msg)
@@ -104,15 +115,15 @@ func (g *Generator) GenRule(rule SchemaRule) {
 func (g *Generator) GenPresenceRule(rule SchemaRule) {
 	typ := rule.Cond1.TypeName()
 	g.Printf("func _Gov_%s_%s(field string, value %s) error {\n", rule.Name, typ, typ)
-	switch typ {
-	case "int64":
-		g.Printf("\tif value == 0 {\n")
-	case "uint64":
-		g.Printf("\tif value <= 0 {\n")
-	case "float64":
-		g.Printf("\tif value == 0 || value == 0.0 {\n")
-	case "string":
-		g.Printf("\tif value == \"\" {\n")
+	switch {
+	case rule.Name == "required" && typ == "any":
+		g.Printf("\tif !_Gov_Present(value) {\n")
+	case rule.Name == "prohibited" && typ == "any":
+		g.Printf("\tif _Gov_Present(value) {\n")
+	case rule.Name == "required":
+		g.Printf("\tif _Gov_IsZero(value) {\n")
+	case rule.Name == "prohibited":
+		g.Printf("\tif !_Gov_IsZero(value) {\n")
 	}
 	g.Printf("\t\treturn _Gov_Error(\"%s\", field, \"\", \"\", \"\")\n", rule.Name)
 	g.Printf("\t}\n")
@@ -132,6 +143,17 @@ func (g *Generator) GenValueConstraintRule(rule SchemaRule) {
 		g.GenRegexpRule(rule)
 	case "email":
 		g.GenEmailRule(rule)
+	case "uuid":
+		g.GenUUIDRule(rule)
+	case "url", "http_url", "ulid", "ip", "ipv4", "ipv6", "cidr", "mac", "port", "hostname", "fqdn",
+		"alpha", "alpha_num", "alpha_dash", "numeric", "ascii", "printable", "lowercase", "uppercase", "no_whitespace":
+		g.GenFormatRule(rule)
+	case "starts_with", "ends_with", "contains", "excludes":
+		g.GenSubstringRule(rule)
+	case "min_bytes", "max_bytes", "min_graphemes", "max_graphemes":
+		g.GenLengthRule(rule)
+	case "luhn", "iban", "isbn10", "isbn13", "e164", "iso3166_alpha2", "iso3166_alpha3", "iso4217", "bcp47":
+		g.GenIdentifierRule(rule)
 	}
 }
 
@@ -144,12 +166,12 @@ func (g *Generator) GenRangeRule(rule SchemaRule) {
 
 func (g *Generator) GenConditionalRule(rule SchemaRule) {
 	switch rule.Name {
-	case "required_if":
+	case "required_if", "required_unless":
 		g.GenRequiredIfRule(rule)
-	case "required_with":
+	case "required_with", "required_with_all", "required_without", "required_without_all":
 		g.GenRequiredWithRule(rule)
-	case "required_without":
-		g.GenRequiredWithoutRule(rule)
+	case "prohibited_if", "prohibited_unless":
+		g.GenProhibitedIfRule(rule)
 	case "same":
 		g.GenSameRule(rule)
 	case "different":
@@ -158,39 +180,45 @@ func (g *Generator) GenConditionalRule(rule SchemaRule) {
 }
 
 func (g *Generator) GenRequiredIfRule(rule SchemaRule) {
-	g.AddImport("github.com/spf13/cast")
-	g.Printf("func _Gov_%s(field1 string, value1 any, field2 string, value2 any, cond any) error {\n", rule.Name)
-	g.Printf("\tv1, v2, c := cast.ToString(value1), cast.ToString(value2), cast.ToString(cond)\n")
-	g.Printf("\tif v2 == c {\n")
-	g.Printf("\t\tif v1 == \"\" || v1 == \"0\" {\n")
-	g.Printf("\t\t\treturn _Gov_Error(\"%s\", field1, \"\", field2, c)\n", rule.Name)
-	g.Printf("\t\t}\n")
+	g.Printf("func _Gov_%s(field1 string, value1 any, fields []string, values []any, conds []string) error {\n", rule.Name)
+	if rule.Name == "required_unless" {
+		g.Printf("\tif !_Gov_In(values[0], conds) && !_Gov_Present(value1) {\n")
+	} else {
+		g.Printf("\tif _Gov_In(values[0], conds) && !_Gov_Present(value1) {\n")
+	}
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, \"\", fields[0], strings.Join(conds, \", \"))\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
 }
 
 func (g *Generator) GenRequiredWithRule(rule SchemaRule) {
-	g.AddImport("github.com/spf13/cast")
-	g.Printf("func _Gov_%s(field1 string, value1 any, field2 string, value2 any, cond any) error {\n", rule.Name)
-	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(value2)\n")
-	g.Printf("\tif v2 != \"\" && v2 != \"0\" {\n")
-	g.Printf("\t\tif v1 == \"\" || v1 == \"0\" {\n")
-	g.Printf("\t\t\treturn _Gov_Error(\"%s\", field1, \"\", field2, \"\")\n", rule.Name)
-	g.Printf("\t\t}\n")
+	g.Printf("func _Gov_%s(field1 string, value1 any, fields []string, values []any, conds []string) error {\n", rule.Name)
+	switch rule.Name {
+	case "required_with": // Any of the fields is present.
+		g.Printf("\tok := !slices.ContainsFunc(values, _Gov_Present)\n")
+	case "required_with_all": // All of the fields are present.
+		g.Printf("\tok := slices.ContainsFunc(values, func(v any) bool { return !_Gov_Present(v) })\n")
+	case "required_without": // Any of the fields is not present.
+		g.Printf("\tok := !slices.ContainsFunc(values, func(v any) bool { return !_Gov_Present(v) })\n")
+	case "required_without_all": // None of the fields is present.
+		g.Printf("\tok := slices.ContainsFunc(values, _Gov_Present)\n")
+	}
+	g.Printf("\tif !ok && !_Gov_Present(value1) {\n")
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, \"\", strings.Join(fields, \", \"), \"\")\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
 }
 
-func (g *Generator) GenRequiredWithoutRule(rule SchemaRule) {
-	g.AddImport("github.com/spf13/cast")
-	g.Printf("func _Gov_%s(field1 string, value1 any, field2 string, value2 any, cond any) error {\n", rule.Name)
-	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(value2)\n")
-	g.Printf("\tif v2 == \"\" || v2 == \"0\" {\n")
-	g.Printf("\t\tif v1 == \"\" || v1 == \"0\" {\n")
-	g.Printf("\t\t\treturn _Gov_Error(\"%s\", field1, v1, field2, v2)\n", rule.Name)
-	g.Printf("\t\t}\n")
+func (g *Generator) GenProhibitedIfRule(rule SchemaRule) {
+	g.Printf("func _Gov_%s(field1 string, value1 any, fields []string, values []any, conds []string) error {\n", rule.Name)
+	if rule.Name == "prohibited_unless" {
+		g.Printf("\tif !_Gov_In(values[0], conds) && _Gov_Present(value1) {\n")
+	} else {
+		g.Printf("\tif _Gov_In(values[0], conds) && _Gov_Present(value1) {\n")
+	}
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, \"\", fields[0], strings.Join(conds, \", \"))\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
@@ -198,10 +226,10 @@ func (g *Generator) GenRequiredWithoutRule(rule SchemaRule) {
 
 func (g *Generator) GenSameRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
-	g.Printf("func _Gov_%s(field1 string, value1 any, field2 string, value2 any, cond any) error {\n", rule.Name)
-	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(value2)\n")
+	g.Printf("func _Gov_%s(field1 string, value1 any, fields []string, values []any, conds []string) error {\n", rule.Name)
+	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(values[0])\n")
 	g.Printf("\tif v1 != v2 {\n")
-	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, v1, field2, v2)\n", rule.Name)
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, v1, fields[0], v2)\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
@@ -209,10 +237,10 @@ func (g *Generator) GenSameRule(rule SchemaRule) {
 
 func (g *Generator) GenDifferentRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
-	g.Printf("func _Gov_%s(field1 string, value1 any, field2 string, value2 any, cond any) error {\n", rule.Name)
-	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(value2)\n")
+	g.Printf("func _Gov_%s(field1 string, value1 any, fields []string, values []any, conds []string) error {\n", rule.Name)
+	g.Printf("\tv1, v2 := cast.ToString(value1), cast.ToString(values[0])\n")
 	g.Printf("\tif v1 == v2 {\n")
-	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, v1, field2, v2)\n", rule.Name)
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field1, v1, fields[0], v2)\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
@@ -221,19 +249,14 @@ func (g *Generator) GenDifferentRule(rule SchemaRule) {
 func (g *Generator) GenBetweenRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
 	typ := rule.Cond1.TypeName()
+	if typ == "string" {
+		g.GenLengthRule(rule)
+		return
+	}
 	g.Printf("func _Gov_%s_%s(field string, value, min, max %s) error {\n", rule.Name, typ, typ)
 	g.Printf("\tn, m := cast.ToString(min), cast.ToString(max)\n")
-	switch typ {
-	case "int64":
-		g.Printf("\tif value < min || value > max {\n")
-	case "unit64":
-		g.Printf("\tif value <= min || value >= max {\n")
-	case "float64":
-		g.Printf("\tif value < min || value > max {\n")
-	case "string":
-		g.Printf("\tif len(value) < min || len(value) > max {\n")
-	}
-	g.Printf("\t\treturn _Gov_Error(\"%s\", field, \"\", n, m)\n", rule.Name)
+	g.Printf("\tif value < min || value > max {\n")
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field, n, \"\", m)\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
@@ -242,17 +265,12 @@ func (g *Generator) GenBetweenRule(rule SchemaRule) {
 func (g *Generator) GenMinRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
 	typ := rule.Cond1.TypeName()
-	g.Printf("func _Gov_%s_%s(field string, value %s, cond %s) error {\n", rule.Name, typ, typ, typ)
-	switch typ {
-	case "int64":
-		g.Printf("\tif value < %d {\n", rule.Cond1.Value)
-	case "unit64":
-		g.Printf("\tif value <= %d {\n", rule.Cond1.Value)
-	case "float64":
-		g.Printf("\tif value < %f {\n", rule.Cond1.Value)
-	case "string":
-		g.Printf("\tif len(value) < %d {\n", rule.Cond1.Value)
+	if typ == "string" {
+		g.GenLengthRule(rule)
+		return
 	}
+	g.Printf("func _Gov_%s_%s(field string, value %s, cond %s) error {\n", rule.Name, typ, typ, typ)
+	g.Printf("\tif value < cond {\n")
 	g.Printf("\t\treturn _Gov_Error(\"%s\", field, cast.ToString(cond), \"\", \"\")\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
@@ -262,17 +280,12 @@ func (g *Generator) GenMinRule(rule SchemaRule) {
 func (g *Generator) GenMaxRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
 	typ := rule.Cond1.TypeName()
-	g.Printf("func _Gov_%s_%s(field string, value %s, cond %s) error {\n", rule.Name, typ, typ, typ)
-	switch typ {
-	case "int64":
-		g.Printf("\tif value > %d {\n", rule.Cond1.Value)
-	case "unit64":
-		g.Printf("\tif value >= %d {\n", rule.Cond1.Value)
-	case "float64":
-		g.Printf("\tif value > %f {\n", rule.Cond1.Value)
-	case "string":
-		g.Printf("\tif len(value) > %d {\n", rule.Cond1.Value)
+	if typ == "string" {
+		g.GenLengthRule(rule)
+		return
 	}
+	g.Printf("func _Gov_%s_%s(field string, value %s, cond %s) error {\n", rule.Name, typ, typ, typ)
+	g.Printf("\tif value > cond {\n")
 	g.Printf("\t\treturn _Gov_Error(\"%s\", field, cast.ToString(cond), \"\", \"\")\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
@@ -282,6 +295,10 @@ func (g *Generator) GenMaxRule(rule SchemaRule) {
 func (g *Generator) GenSizeRule(rule SchemaRule) {
 	g.AddImport("github.com/spf13/cast")
 	t := rule.Cond1.TypeName()
+	if t == "string" {
+		g.GenLengthRule(rule)
+		return
+	}
 	g.Printf("func _Gov_%s_%s(field string, value %s, cond %s) error {\n", rule.Name, t, t, t)
 	g.Printf("\tv := cast.ToString(value)\n")
 	g.Printf("\tif len(v) != int(cond) {\n")
@@ -291,6 +308,35 @@ func (g *Generator) GenSizeRule(rule SchemaRule) {
 	g.Printf("}\n")
 }
 
+// GenLengthRule generates a length check of a string, min, max, size and
+// between count runes, the _bytes and _graphemes rules count bytes and
+// user-perceived characters. Rune rules report the <rule>_string message.
+func (g *Generator) GenLengthRule(rule SchemaRule) {
+	g.AddImport("github.com/spf13/cast")
+	name, key, count := rule.Name, rule.Name+"_string", "utf8.RuneCountInString(value)"
+	switch {
+	case strings.HasSuffix(name, "_bytes"):
+		name, key, count = strings.TrimSuffix(name, "_bytes"), rule.Name, "len(value)"
+	case strings.HasSuffix(name, "_graphemes"):
+		name, key, count = strings.TrimSuffix(name, "_graphemes"), rule.Name, "_Gov_Graphemes(value)"
+	default:
+		g.AddImport("unicode/utf8")
+	}
+	if name == "between" {
+		g.Printf("func _Gov_%s_string(field string, value, min, max string) error {\n", rule.Name)
+		g.Printf("\tif n := %s; n < cast.ToInt(min) || n > cast.ToInt(max) {\n", count)
+		g.Printf("\t\treturn _Gov_Error(\"%s\", field, min, \"\", max)\n", key)
+	} else {
+		op := map[string]string{"min": "<", "max": ">", "size": "!="}[name]
+		g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
+		g.Printf("\tif %s %s cast.ToInt(cond) {\n", count, op)
+		g.Printf("\t\treturn _Gov_Error(\"%s\", field, cond, \"\", \"\")\n", key)
+	}
+	g.Printf("\t}\n")
+	g.Printf("\treturn nil\n")
+	g.Printf("}\n")
+}
+
 func (g *Generator) GenRegexpRule(rule SchemaRule) {
 	g.AddImport("regexp")
 	g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
@@ -303,107 +349,598 @@ func (g *Generator) GenRegexpRule(rule SchemaRule) {
 	g.Printf("}\n")
 }
 
+// GenEmailRule generates a RFC 5322 address check, cond holds the comma
+// separated options of the rule, e.g. strict,deny_domains:example.com.
 func (g *Generator) GenEmailRule(rule SchemaRule) {
 	g.Printf("func _Gov_%s_string(field string, value string, cond %s) error {\n", rule.Name, rule.Cond1.TypeName())
-	g.Printf("\tatIndex, dotIndex := strings.Index(value, \"@\"), strings.LastIndex(value, \".\")\n")
-	g.Printf("\tif atIndex < 1 || dotIndex < atIndex+2 || dotIndex+2 >= len(value) {\n")
+	g.Printf("\topts := strings.Split(cond, \",\")\n")
+	g.Printf("\tdomain, ok := govader.Email(value, opts)\n")
+	g.Printf("\tif !ok {\n")
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field, \"\", \"\", \"\")\n", rule.Name)
+	g.Printf("\t}\n")
+	g.Printf("\tif !govader.EmailDomain(domain, opts) {\n")
+	g.Printf("\t\treturn _Gov_Error(\"email_domain\", field, domain, \"\", \"\")\n")
+	g.Printf("\t}\n")
+	g.Printf("\treturn nil\n")
+	g.Printf("}\n")
+}
+
+// GenUUIDRule generates a UUID check, cond is the version if any.
+func (g *Generator) GenUUIDRule(rule SchemaRule) {
+	g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
+	g.Printf("\tif !govader.UUID(value, cond) {\n")
+	g.Printf("\t\tif cond != \"\" {\n")
+	g.Printf("\t\t\tcond = \"v\" + cond\n")
+	g.Printf("\t\t}\n")
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field, cond, \"\", \"\")\n", rule.Name)
+	g.Printf("\t}\n")
+	g.Printf("\treturn nil\n")
+	g.Printf("}\n")
+}
+
+// identifierFuncs maps the identifier rules to the runtime function
+// checking them against checksums or the embedded code tables.
+var identifierFuncs = map[string]string{
+	"luhn":           "govader.Luhn",
+	"iban":           "govader.IBAN",
+	"isbn10":         "govader.ISBN10",
+	"isbn13":         "govader.ISBN13",
+	"e164":           "govader.E164",
+	"iso3166_alpha2": "govader.ISO3166Alpha2",
+	"iso3166_alpha3": "govader.ISO3166Alpha3",
+	"iso4217":        "govader.ISO4217",
+	"bcp47":          "govader.BCP47",
+}
+
+func (g *Generator) GenIdentifierRule(rule SchemaRule) {
+	g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
+	g.Printf("\tif !%s(value) {\n", identifierFuncs[rule.Name])
 	g.Printf("\t\treturn _Gov_Error(\"%s\", field, \"\", \"\", \"\")\n", rule.Name)
 	g.Printf("\t}\n")
 	g.Printf("\treturn nil\n")
 	g.Printf("}\n")
 }
 
+// formatFuncs maps the format, network and character class rules to the
+// runtime function checking them, letters and digits are Unicode-aware.
+var formatFuncs = map[string]string{
+	"url":           "govader.URL",
+	"http_url":      "govader.HTTPURL",
+	"ulid":          "govader.ULID",
+	"ip":            "govader.IP",
+	"ipv4":          "govader.IPv4",
+	"ipv6":          "govader.IPv6",
+	"cidr":          "govader.CIDR",
+	"mac":           "govader.MAC",
+	"port":          "govader.Port",
+	"hostname":      "govader.Hostname",
+	"fqdn":          "govader.FQDN",
+	"alpha":         "govader.Alpha",
+	"alpha_num":     "govader.AlphaNum",
+	"alpha_dash":    "govader.AlphaDash",
+	"numeric":       "govader.Numeric",
+	"ascii":         "govader.ASCII",
+	"printable":     "govader.Printable",
+	"lowercase":     "govader.Lowercase",
+	"uppercase":     "govader.Uppercase",
+	"no_whitespace": "govader.NoWhitespace",
+}
+
+func (g *Generator) GenFormatRule(rule SchemaRule) {
+	g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
+	g.Printf("\tif !%s(value) {\n", formatFuncs[rule.Name])
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field, \"\", \"\", \"\")\n", rule.Name)
+	g.Printf("\t}\n")
+	g.Printf("\treturn nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) GenSubstringRule(rule SchemaRule) {
+	g.Printf("func _Gov_%s_string(field string, value string, cond string) error {\n", rule.Name)
+	switch rule.Name {
+	case "starts_with":
+		g.Printf("\tif !strings.HasPrefix(value, cond) {\n")
+	case "ends_with":
+		g.Printf("\tif !strings.HasSuffix(value, cond) {\n")
+	case "contains":
+		g.Printf("\tif !strings.Contains(value, cond) {\n")
+	case "excludes":
+		g.Printf("\tif strings.Contains(value, cond) {\n")
+	}
+	g.Printf("\t\treturn _Gov_Error(\"%s\", field, cond, \"\", \"\")\n", rule.Name)
+	g.Printf("\t}\n")
+	g.Printf("\treturn nil\n")
+	g.Printf("}\n")
+}
+
 func (g *Generator) GenSchmaValdation(schema Schema) {
 	// Define the schema struct type
 	g.Printf("type %sSchema struct {\n", schema.Type.Name)
-	g.Printf("\trules []_Gov_Rule\n")
+	g.Printf("\tfields []_Gov_Field\n")
+	g.Printf("\topts   _Gov_Options\n")
 	g.Printf("}\n\n")
 
 	// Define the constructor function for the schema
 	g.Printf("func New%sSchema(u %s) %sSchema {\n", schema.Type.Name, schema.Type.Name, schema.Type.Name)
 	g.Printf("\treturn %sSchema{\n", schema.Type.Name)
-	g.Printf("\t\trules: []_Gov_Rule{\n")
-
-	for _, rule := range schema.Rules {
-		switch rule.Type {
-		case rulePresence:
-			// Generate presence rule
-			g.Printf("\t\t\t_Gov_RulePresence[%s]{\n", rule.Cond1.TypeName())
-			g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
-			g.Printf("\t\t\t\tValue:     %s(u.%s),\n", rule.Cond1.TypeName(), rule.Field1)
-			g.Printf("\t\t\t\tValidator: _Gov_required_%s,\n", rule.Cond1.TypeName())
-			g.Printf("\t\t\t},\n")
-
-		case ruleValueConstraint:
-			// Generate value constraint rule (e.g., min, max, regexp)
-			typ := rule.Cond1.TypeName()
-			if rule.Name == "regexp" {
-				typ = "string"
-			}
-			g.Printf("\t\t\t_Gov_RuleValueConstraint[%s]{\n", typ)
-			g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
-			if rule.Name == "regexp" {
-				g.Printf("\t\t\t\tValue:     cast.ToString(u.%s),\n", rule.Field1)
-			} else {
-				g.Printf("\t\t\t\tValue:     %s(u.%s),\n", typ, rule.Field1)
+	g.Printf("\t\tfields: []_Gov_Field{\n")
+
+	// Group rules by field, in the order fields are declared.
+	for _, field := range schema.Type.FieldList {
+		rules := slices.DeleteFunc(slices.Clone(schema.Rules), func(r SchemaRule) bool {
+			return r.Field1 != field.Name
+		})
+		// exclude_if rules skip all other rules of the field.
+		var excludes []string
+		rules = slices.DeleteFunc(rules, func(r SchemaRule) bool {
+			if r.Name != "exclude_if" {
+				return false
 			}
-			if rule.Cond1 != nil {
-				// Handle condition only if not nil, handle non presetValConstRules.
-				if rule.Name == "regexp" {
-					g.Printf("\t\t\t\tCond:      `%s`,\n", rule.Cond1.Value)
+			excludes = append(excludes, fmt.Sprintf("_Gov_In(u.%s, %#v)", r.Field2, strings.Split(r.Cond1.Value.(string), ",")))
+			return true
+		})
+		if len(rules) == 0 {
+			continue
+		}
+		g.Printf("\t\t\t{\n")
+		g.Printf("\t\t\t\tName: \"%s\",\n", field.Name)
+		if schema.HasModifier(field.Name, "bail") {
+			g.Printf("\t\t\t\tBail: true,\n")
+		}
+		sometimes, nullable := schema.HasModifier(field.Name, "sometimes"), schema.HasModifier(field.Name, "nullable")
+		if sometimes {
+			g.Printf("\t\t\t\tSometimes: true,\n")
+		}
+		if nullable {
+			g.Printf("\t\t\t\tNullable: true,\n")
+		}
+		if sometimes || nullable {
+			switch field.typ().Underlying().(type) {
+			case *types.Slice, *types.Map:
+				g.Printf("\t\t\t\tEmpty: len(u.%s) == 0,\n", field.Name)
+			default:
+				if types.Comparable(field.typ()) {
+					g.Printf("\t\t\t\tEmpty: _Gov_IsZero(u.%s),\n", field.Name)
 				} else {
-					if rule.Cond1.Value != nil {
-						g.Printf("\t\t\t\tCond:      %v,\n", rule.Cond1.Value)
-					}
+					g.Printf("\t\t\t\tEmpty: !_Gov_Present(u.%s),\n", field.Name)
 				}
 			}
-			g.Printf("\t\t\t\tValidator: _Gov_%s_%s,\n", rule.Name, typ)
-			g.Printf("\t\t\t},\n")
-
-		case ruleRange:
-			// Generate range rule (e.g., between)
-			g.Printf("\t\t\t_Gov_RuleRange[%s]{\n", rule.Cond1.TypeName())
-			g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
-			g.Printf("\t\t\t\tValue:     %s(u.%s),\n", rule.Cond1.TypeName(), rule.Field1)
-			if rule.Cond1 != nil {
-				g.Printf("\t\t\t\tMin:       %v,\n", rule.Cond1.Value)
-			}
-			if rule.Cond2 != nil {
-				g.Printf("\t\t\t\tMax:       %v,\n", rule.Cond2.Value)
-			}
-			g.Printf("\t\t\t\tValidator: _Gov_between_%s,\n", rule.Cond1.TypeName())
-			g.Printf("\t\t\t},\n")
+		}
+		if len(excludes) > 0 {
+			g.Printf("\t\t\t\tExclude: %s,\n", strings.Join(excludes, " || "))
+		}
+		g.Printf("\t\t\t\tRules: []_Gov_Rule{\n")
+		for _, rule := range rules {
+			g.GenSchemaRule(rule)
+		}
+		g.Printf("\t\t\t\t},\n")
+		g.Printf("\t\t\t},\n")
+	}
 
-		case ruleConditional:
-			// Generate conditional rule
-			g.Printf("\t\t\t_Gov_RuleConditional{\n")
-			g.Printf("\t\t\t\tField1:    \"%s\",\n", rule.Field1)
-			g.Printf("\t\t\t\tField2:    \"%s\",\n", rule.Field2)
-			g.Printf("\t\t\t\tValue1:    u.%s,\n", rule.Field1)
-			g.Printf("\t\t\t\tValue2:    u.%s,\n", rule.Field2)
-			if rule.Cond1 != nil {
-				g.Printf("\t\t\t\tCond:      \"%v\",\n", rule.Cond1.Value)
-			}
-			g.Printf("\t\t\t\tValidator: _Gov_%s,\n", rule.Name)
-			g.Printf("\t\t\t},\n")
+	// Group rules are not bound to a single field.
+	if rules := slices.DeleteFunc(slices.Clone(schema.Rules), func(r SchemaRule) bool {
+		return r.Type != ruleGroup
+	}); len(rules) > 0 {
+		g.Printf("\t\t\t{\n")
+		g.Printf("\t\t\t\tRules: []_Gov_Rule{\n")
+		for _, rule := range rules {
+			g.GenSchemaRule(rule)
 		}
+		g.Printf("\t\t\t\t},\n")
+		g.Printf("\t\t\t},\n")
 	}
 
-	// Close the rules slice and return statement
+	// Struct-level validation hook runs after the tag rules.
+	if schema.Type.Hook {
+		g.Printf("\t\t\t{\n")
+		g.Printf("\t\t\t\tRules: []_Gov_Rule{\n")
+		g.Printf("\t\t\t\t\t_Gov_RuleStruct{Hook: u.ValidateStruct},\n")
+		g.Printf("\t\t\t\t},\n")
+		g.Printf("\t\t\t},\n")
+	}
+
+	// Close the fields slice and return statement
 	g.Printf("\t\t},\n")
 	g.Printf("\t}\n")
 	g.Printf("}\n")
 	g.Printf("\n")
 
+	// Generate the StopAfter option for the schema.
+	g.Printf("// StopAfter returns a copy of the schema which stops validating after n errors.\n")
+	g.Printf("func (s %sSchema) StopAfter(n int) %sSchema {\n", schema.Type.Name, schema.Type.Name)
+	g.Printf("\ts.opts.MaxErrors = n\n")
+	g.Printf("\treturn s\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+
 	// Generate the Validate method for the schema.
 	g.Printf("func (s %sSchema) Validate() (messages []string) {\n", schema.Type.Name)
-	g.Printf("\tfor _, rule := range s.rules {\n")
-	g.Printf("\t\tif err := rule.Validate(); err != nil {\n")
-	g.Printf("\t\t\tmessages = append(messages, err.Error())\n")
-	g.Printf("\t\t}\n")
-	g.Printf("\t}\n")
+	g.Printf("\tmessages, _ = _Gov_Validate(s.fields, s.opts)\n")
+	g.Printf("\treturn messages\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+
+	// Generate the ValidateGroups method for the schema.
+	g.Printf("// ValidateGroups validates rules without groups and rules of the given groups.\n")
+	g.Printf("func (s %sSchema) ValidateGroups(groups ...string) (messages []string) {\n", schema.Type.Name)
+	g.Printf("\ts.opts.Groups = groups\n")
+	g.Printf("\tmessages, _ = _Gov_Validate(s.fields, s.opts)\n")
 	g.Printf("\treturn messages\n")
 	g.Printf("}\n")
+	g.Printf("\n")
+
+	// Generate the ValidateContext method for the schema.
+	g.Printf("// ValidateContext validates the schema including rules which need I/O,\n")
+	g.Printf("// it stops and returns the error if ctx is done or a service fails.\n")
+	g.Printf("func (s %sSchema) ValidateContext(ctx context.Context, svc govader.Services) ([]string, error) {\n", schema.Type.Name)
+	g.Printf("\ts.opts.Context, s.opts.Services = ctx, svc\n")
+	g.Printf("\treturn _Gov_Validate(s.fields, s.opts)\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+
+	// Generate the ValidateFields method for the schema.
+	g.Printf("// ValidateFields validates only the rules of the given field paths and the\n")
+	g.Printf("// cross-field rules depending on them, unknown paths are reported as errors.\n")
+	g.Printf("func (s %sSchema) ValidateFields(paths ...string) (messages []string) {\n", schema.Type.Name)
+	g.Printf("\tnames := []string{")
+	for i, name := range schema.Type.FieldNames {
+		if i > 0 {
+			g.Printf(", ")
+		}
+		g.Printf("%q", name)
+	}
+	g.Printf("}\n")
+	g.Printf("\ts.opts.Partial, s.opts.Fields = true, paths\n")
+	g.Printf("\tmessages, _ = _Gov_Validate(s.fields, s.opts)\n")
+	g.Printf("\treturn append(_Gov_UnknownFields(names, paths), messages...)\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+
+	if len(schema.Defaults) > 0 {
+		g.GenApplyDefaults(schema)
+	}
+	if len(schema.Sanitizers) > 0 {
+		g.GenNormalize(schema)
+	}
+	if len(schema.Defaults) > 0 || len(schema.Sanitizers) > 0 {
+		g.GenValidateAndNormalize(schema)
+	}
+
+	// Register the schema into the runtime registry.
+	g.Printf("func init() {\n")
+	g.Printf("\tgovader.Register(func(u %s) govader.Validatable {\n", schema.Type.Name)
+	g.Printf("\t\treturn New%sSchema(u)\n", schema.Type.Name)
+	g.Printf("\t})\n")
+	if len(schema.Defaults) > 0 || len(schema.Sanitizers) > 0 {
+		g.Printf("\tgovader.RegisterNormalizer(_Gov_Normalize%s)\n", schema.Type.Name)
+	}
+	g.Printf("}\n")
+}
+
+func (g *Generator) GenSchemaRule(rule SchemaRule) {
+	switch rule.Type {
+	case rulePresence:
+		// Generate presence rule
+		g.Printf("\t\t\t_Gov_RulePresence[%s]{\n", rule.Cond1.TypeName())
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:      \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue:     %s(u.%s),\n", rule.Cond1.TypeName(), rule.Field1)
+		g.Printf("\t\t\t\tValidator: _Gov_%s_%s,\n", rule.Name, rule.Cond1.TypeName())
+		g.Printf("\t\t\t},\n")
+
+	case ruleValueConstraint:
+		// Generate value constraint rule (e.g., min, max, regexp)
+		typ := rule.Cond1.TypeName()
+		if rule.Name == "regexp" {
+			typ = "string"
+		}
+		g.Printf("\t\t\t_Gov_RuleValueConstraint[%s]{\n", typ)
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
+		if rule.Name == "regexp" {
+			g.Printf("\t\t\t\tValue:     cast.ToString(u.%s),\n", rule.Field1)
+		} else {
+			g.Printf("\t\t\t\tValue:     %s(u.%s),\n", typ, rule.Field1)
+		}
+		if rule.Cond1 != nil {
+			// Handle condition only if not nil, handle non presetValConstRules.
+			if rule.Name == "regexp" {
+				g.Printf("\t\t\t\tCond:      `%s`,\n", rule.Cond1.Value)
+			} else if typ == "string" {
+				if rule.Cond1.Value != nil {
+					g.Printf("\t\t\t\tCond:      %q,\n", rule.Cond1.Value)
+				}
+			} else {
+				if rule.Cond1.Value != nil {
+					g.Printf("\t\t\t\tCond:      %v,\n", rule.Cond1.Value)
+				}
+			}
+		}
+		g.Printf("\t\t\t\tValidator: _Gov_%s_%s,\n", rule.Name, typ)
+		g.Printf("\t\t\t},\n")
+		if rule.Name == "email" && rule.Cond1.Value != nil && slices.Contains(strings.Split(rule.Cond1.Value.(string), ","), "mx") {
+			// The domain check needs I/O and runs as a service rule.
+			g.Printf("\t\t\t_Gov_RuleMX{\n")
+			g.GenRuleGroups(rule)
+			g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+			g.Printf("\t\t\t\tValue: string(u.%s),\n", rule.Field1)
+			g.Printf("\t\t\t},\n")
+		}
+
+	case ruleRange:
+		// Generate range rule (e.g., between)
+		g.Printf("\t\t\t_Gov_RuleRange[%s]{\n", rule.Cond1.TypeName())
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue:     %s(u.%s),\n", rule.Cond1.TypeName(), rule.Field1)
+		if rule.Cond1 != nil {
+			g.Printf("\t\t\t\tMin:       %s,\n", rule.Cond1.Literal())
+		}
+		if rule.Cond2 != nil {
+			g.Printf("\t\t\t\tMax:       %s,\n", rule.Cond2.Literal())
+		}
+		g.Printf("\t\t\t\tValidator: _Gov_between_%s,\n", rule.Cond1.TypeName())
+		g.Printf("\t\t\t},\n")
+
+	case ruleConditional:
+		// Generate conditional rule
+		fields := strings.Split(rule.Field2, ",")
+		values := make([]string, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, "u."+field)
+		}
+		g.Printf("\t\t\t_Gov_RuleConditional{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:      \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField1:    \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tFields:    %#v,\n", fields)
+		g.Printf("\t\t\t\tValue1:    u.%s,\n", rule.Field1)
+		g.Printf("\t\t\t\tValues:    []any{%s},\n", strings.Join(values, ", "))
+		if rule.Cond1 != nil {
+			g.Printf("\t\t\t\tConds:     %#v,\n", strings.Split(rule.Cond1.Value.(string), ","))
+		}
+		g.Printf("\t\t\t\tValidator: _Gov_%s,\n", rule.Name)
+		g.Printf("\t\t\t},\n")
+
+	case ruleService:
+		// Generate rule backed by an injected service (e.g., unique)
+		table, column, _ := strings.Cut(rule.Cond1.Value.(string), ".")
+		g.Printf("\t\t\t_Gov_RuleUnique{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tField:  \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue:  u.%s,\n", rule.Field1)
+		g.Printf("\t\t\t\tTable:  %q,\n", table)
+		g.Printf("\t\t\t\tColumn: %q,\n", column)
+		g.Printf("\t\t\t},\n")
+
+	case ruleExpr:
+		// Generate cross-field expression rule (e.g., expr=End > Start)
+		g.Printf("\t\t\t_Gov_RuleExpr{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tExpr:  %q,\n", rule.Cond1.Value)
+		g.Printf("\t\t\t\tDeps:  %#v,\n", rule.Deps)
+		g.Printf("\t\t\t\tFunc:  func() bool { return %s },\n", rule.Expr)
+		g.Printf("\t\t\t},\n")
+
+	case ruleCompare:
+		// Generate typed comparison with another field (e.g., gte_field:MinPrice)
+		g.Printf("\t\t\t_Gov_RuleCompare{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:   \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField1: \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tField2: \"%s\",\n", rule.Field2)
+		g.Printf("\t\t\t\tFunc:   func() bool { return %s },\n", rule.Expr)
+		g.Printf("\t\t\t},\n")
+
+	case ruleIn:
+		// Generate rule checking the field against a list of values (e.g., in=a,b)
+		g.Printf("\t\t\t_Gov_RuleIn{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:   \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField:  \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValues: %q,\n", rule.Cond1.Value)
+		g.Printf("\t\t\t\tFunc:   func() bool { return %s },\n", rule.Expr)
+		g.Printf("\t\t\t},\n")
+
+	case ruleNumeric:
+		// Generate comparison on a numeric field (e.g., gt=0, multiple_of=5)
+		g.AddImport(rule.Imports...)
+		value2 := ""
+		if rule.Cond2 != nil {
+			value2 = rule.Cond2.Value.(string)
+		}
+		g.Printf("\t\t\t_Gov_RuleNumeric{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:   \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField:  \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue1: %q,\n", rule.Cond1.Value)
+		g.Printf("\t\t\t\tValue2: %q,\n", value2)
+		g.Printf("\t\t\t\tFunc:   func() bool { return %s },\n", rule.Expr)
+		g.Printf("\t\t\t},\n")
+
+	case ruleNet:
+		// Generate rule on a network address or URL field (e.g., private, scheme=https)
+		g.Printf("\t\t\t_Gov_RuleNet{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:  \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue: %q,\n", rule.Cond1.Value)
+		g.Printf("\t\t\t\tFunc:  func() bool { return %s },\n", rule.Expr)
+		g.Printf("\t\t\t},\n")
+
+	case ruleCollection:
+		// Generate rule on the elements of a slice field (e.g., unique=SKU, sum(Percent)=100)
+		g.AddImport(rule.Imports...)
+		g.Printf("\t\t\t_Gov_RuleCollection{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:  \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+		if rule.Field2 != "" {
+			g.Printf("\t\t\t\tKey:   \"%s\",\n", rule.Field2)
+		}
+		g.Printf("\t\t\t\tValue: %q,\n", rule.Cond1.Value)
+		if rule.Name == "distinct" || rule.Name == "unique" {
+			g.Printf("\t\t\t\tDup:   func() (int, int) { return %s },\n", rule.Expr)
+		} else {
+			g.Printf("\t\t\t\tFunc:  func() bool { return %s },\n", rule.Expr)
+		}
+		g.Printf("\t\t\t},\n")
+
+	case rulePassword:
+		// Generate password policy rule (e.g., password=min:12,classes:upper|digit)
+		policy, _ := parsePasswordPolicy(rule.Cond1.Value.(string))
+		values := make([]string, 0, len(policy.Not))
+		for _, field := range policy.Not {
+			values = append(values, fmt.Sprintf("string(u.%s)", field))
+		}
+		g.Printf("\t\t\t_Gov_RulePassword{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tField:     \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tValue:     string(u.%s),\n", rule.Field1)
+		g.Printf("\t\t\t\tMin:       %d,\n", policy.Min)
+		if len(policy.Classes) > 0 {
+			g.Printf("\t\t\t\tClasses:   %#v,\n", policy.Classes)
+		}
+		if policy.MaxRepeat > 0 {
+			g.Printf("\t\t\t\tMaxRepeat: %d,\n", policy.MaxRepeat)
+		}
+		if len(policy.Not) > 0 {
+			g.Printf("\t\t\t\tNot:       %#v,\n", policy.Not)
+			g.Printf("\t\t\t\tValues:    []string{%s},\n", strings.Join(values, ", "))
+		}
+		g.Printf("\t\t\t},\n")
+		if policy.Breached {
+			// The breach lookup needs I/O and runs as a service rule.
+			g.Printf("\t\t\t_Gov_RuleBreached{\n")
+			g.GenRuleGroups(rule)
+			g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+			g.Printf("\t\t\t\tValue: string(u.%s),\n", rule.Field1)
+			g.Printf("\t\t\t},\n")
+		}
+
+	case ruleGroup:
+		// Generate rule on a group of fields (e.g., one_of_required=Email,Phone)
+		values := make([]string, 0, len(rule.Deps))
+		for _, field := range rule.Deps {
+			values = append(values, "u."+field)
+		}
+		g.Printf("\t\t\t_Gov_RuleGroup{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:   \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tFields: %#v,\n", rule.Deps)
+		g.Printf("\t\t\t\tValues: []any{%s},\n", strings.Join(values, ", "))
+		g.Printf("\t\t\t},\n")
+
+	case ruleCustom:
+		// Generate rule implemented by a function of the package
+		args, values := []string{"u." + rule.Field1}, []string{}
+		for _, arg := range rule.Args {
+			args = append(args, arg.Literal())
+			values = append(values, fmt.Sprint(arg.Value))
+		}
+		g.Printf("\t\t\t_Gov_RuleCustom{\n")
+		g.GenRuleGroups(rule)
+		g.Printf("\t\t\t\tName:  \"%s\",\n", rule.Name)
+		g.Printf("\t\t\t\tField: \"%s\",\n", rule.Field1)
+		g.Printf("\t\t\t\tArgs:  %q,\n", strings.Join(values, ","))
+		g.Printf("\t\t\t\tFunc:  func() error { return %s(%s) },\n", rule.Func, strings.Join(args, ", "))
+		g.Printf("\t\t\t},\n")
+	}
+}
+
+// sanitizerFuncs maps sanitizers to the call applying them to a string.
+var sanitizerFuncs = map[string]string{
+	"trim":            "strings.TrimSpace(%s)",
+	"lower":           "strings.ToLower(%s)",
+	"upper":           "strings.ToUpper(%s)",
+	"nfc":             "govader.NFC(%s)",
+	"nfkc":            "govader.NFKC(%s)",
+	"collapse_spaces": "govader.CollapseSpaces(%s)",
+	"strip_control":   "govader.StripControl(%s)",
+}
+
+// GenNormalize generates the function applying the sanitizers of the fields
+// in tag order and the function validating the normalized value.
+func (g *Generator) GenNormalize(schema Schema) {
+	name := schema.Type.Name
+	g.Printf("// Normalize%s applies the sanitizers of the fields of u in place.\n", name)
+	g.Printf("func Normalize%s(u *%s) {\n", name, name)
+	for _, field := range schema.Type.FieldList {
+		funcs := schema.Sanitizers[field.Name]
+		if len(funcs) == 0 {
+			continue
+		}
+		expr := "u." + field.Name
+		named, isNamed := field.typ().(*types.Named)
+		if isNamed {
+			expr = "string(" + expr + ")"
+		}
+		for _, fn := range funcs {
+			expr = fmt.Sprintf(sanitizerFuncs[fn], expr)
+		}
+		if isNamed {
+			expr = named.Obj().Name() + "(" + expr + ")"
+		}
+		g.Printf("\tu.%s = %s\n", field.Name, expr)
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+// GenApplyDefaults generates the function setting zero-valued fields to
+// their default values.
+func (g *Generator) GenApplyDefaults(schema Schema) {
+	name := schema.Type.Name
+	g.Printf("// ApplyDefaults%s sets the zero-valued fields of u to their defaults.\n", name)
+	g.Printf("func ApplyDefaults%s(u *%s) {\n", name, name)
+	for _, field := range schema.Type.FieldList {
+		if def := schema.Defaults[field.Name]; def != nil {
+			g.Printf("\tif _Gov_IsZero(u.%s) {\n", field.Name)
+			g.Printf("\t\tu.%s = %s\n", field.Name, def.Literal())
+			g.Printf("\t}\n")
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+// GenValidateAndNormalize generates the function applying the defaults and
+// the sanitizers of a value before validating it.
+func (g *Generator) GenValidateAndNormalize(schema Schema) {
+	name := schema.Type.Name
+	g.Printf("func _Gov_Normalize%s(u *%s) {\n", name, name)
+	if len(schema.Defaults) > 0 {
+		g.Printf("\tApplyDefaults%s(u)\n", name)
+	}
+	if len(schema.Sanitizers) > 0 {
+		g.Printf("\tNormalize%s(u)\n", name)
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// ValidateAndNormalize%s applies the defaults and sanitizers of u and\n", name)
+	g.Printf("// validates the normalized value.\n")
+	g.Printf("func ValidateAndNormalize%s(u *%s) []string {\n", name, name)
+	g.Printf("\t_Gov_Normalize%s(u)\n", name)
+	g.Printf("\treturn New%sSchema(*u).Validate()\n", name)
+	g.Printf("}\n")
+	g.Printf("\n")
+}
+
+// GenRuleGroups generates the validation groups of a schema rule.
+func (g *Generator) GenRuleGroups(rule SchemaRule) {
+	if len(rule.Groups) == 0 {
+		return
+	}
+	g.Printf("\t\t\t\t_Gov_Groups: _Gov_Groups{")
+	for i, group := range rule.Groups {
+		if i > 0 {
+			g.Printf(", ")
+		}
+		g.Printf("%q", group)
+	}
+	g.Printf("},\n")
 }
 
 func (g *Generator) AddImport(imports ...string) {
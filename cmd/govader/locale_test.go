@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__LoadLocale(t *testing.T) {
+	t.Parallel()
+	data, err := localeFS.ReadFile("locale.json")
+	assert.NoError(t, err)
+	var locales map[string]map[string]string
+	assert.NoError(t, json.Unmarshal(data, &locales))
+
+	keys := slices.Sorted(maps.Keys(LoadLocale("en")))
+	placeholders := []string{":field", ":field1", ":value", ":value1", ":field2", ":value2", ":min", ":max"}
+	for locale := range locales {
+		messages := LoadLocale(locale)
+		assert.Equal(t, keys, slices.Sorted(maps.Keys(messages)), locale)
+		for key, msg := range messages {
+			// Placeholders start a word and may only be followed by
+			// punctuation, as _Gov_Error substitutes them.
+			for _, word := range strings.Split(msg, " ") {
+				i := strings.IndexRune(word, ':')
+				if i == -1 || !strings.ContainsFunc(word[i:], unicode.IsLetter) {
+					continue
+				}
+				assert.Zero(t, i, "%s: %s: %q", locale, key, word)
+				assert.Contains(t, placeholders, strings.TrimRightFunc(word, unicode.IsPunct), "%s: %s: %q", locale, key, word)
+			}
+		}
+	}
+}
@@ -2,16 +2,19 @@ package main
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"flag"
 	"fmt"
 	"go/ast"
 	"go/format"
+	"go/token"
 	"go/types"
 	"log"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -70,16 +73,36 @@ func main() {
 		return
 	}
 
-	schemas, err := parseSchema(typeInfo)
+	for i := range typeInfo {
+		typeInfo[i].Hook, err = findStructHook(pkg, typeInfo[i].Name)
+		if err != nil {
+			log.Fatalf("invalid hook: %s", err)
+		}
+	}
+
+	funcs, err := findRuleFuncs(pkg)
+	if err != nil {
+		log.Fatalf("invalid rule: %s", err)
+	}
+	schemas, err := parseSchema(typeInfo, funcs)
 	if err != nil {
 		log.Fatalf("invalid schema: %s", err)
 	}
 
+	messages := LoadLocale(*locale)
+	for _, fn := range funcs {
+		// Custom rules cannot clash with built-in messages, the message of
+		// the locale is preferred to the default one.
+		if msg := cmp.Or(fn.Messages[*locale], fn.Messages[""]); msg != "" {
+			messages[fn.Name] = msg
+		}
+	}
+
 	buf := new(bytes.Buffer) // Accumulated output.
 	g := &Generator{
 		w:              buf,
 		Schemas:        schemas,
-		Messages:       LoadLocale(*locale),
+		Messages:       messages,
 		GeneratedRules: make(map[string]bool),
 	}
 	tmpl := &Template{
@@ -107,7 +130,7 @@ func main() {
 
 func loadPackage(pattern []string) (*Package, error) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports,
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
 	}
 	pkgs, err := packages.Load(cfg, pattern...)
 	if err != nil {
@@ -137,6 +160,134 @@ func findTypeValues(typeName string, pkg *Package) []StructInfo {
 	return values
 }
 
+// findRuleFuncs finds the functions of the package marked as custom rules
+// with a //govader:rule name=<rule> [message="..."] [message.<locale>="..."]
+// directive.
+func findRuleFuncs(pkg *Package) ([]RuleFunc, error) {
+	var funcs []RuleFunc
+	seen := make(map[string]token.Position)
+	for _, f := range pkg.files {
+		for _, decl := range f.file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Doc == nil {
+				continue
+			}
+			for _, c := range fd.Doc.List {
+				directive, ok := strings.CutPrefix(c.Text, "//govader:rule ")
+				if !ok {
+					continue
+				}
+				pos := pkg.Fset.Position(c.Pos())
+				attrs, err := parseDirective(directive)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s", pos, err)
+				}
+				fn := RuleFunc{
+					Name:     attrs["name"],
+					Func:     fd.Name.Name,
+					Messages: make(map[string]string),
+					Sig:      pkg.TypesInfo.Defs[fd.Name].Type().(*types.Signature),
+				}
+				for key, value := range attrs {
+					if key == "message" {
+						fn.Messages[""] = value
+					} else if locale, ok := strings.CutPrefix(key, "message."); ok {
+						fn.Messages[locale] = value
+					}
+				}
+				if err := checkRuleFunc(fd, fn); err != nil {
+					return nil, fmt.Errorf("%s: %s", pos, err)
+				}
+				if prev, ok := seen[fn.Name]; ok {
+					return nil, fmt.Errorf("%s: rule %s already declared at %s", pos, fn.Name, prev)
+				}
+				seen[fn.Name] = pos
+				funcs = append(funcs, fn)
+			}
+		}
+	}
+	return funcs, nil
+}
+
+// findStructHook reports whether the type has a struct-level validation hook,
+// a ValidateStruct method taking a *govader.Reporter.
+func findStructHook(pkg *Package, typeName string) (bool, error) {
+	obj := pkg.Types.Scope().Lookup(typeName)
+	if obj == nil {
+		return false, nil
+	}
+	sel := types.NewMethodSet(types.NewPointer(obj.Type())).Lookup(pkg.Types, "ValidateStruct")
+	if sel == nil {
+		return false, nil
+	}
+	sig := sel.Type().(*types.Signature)
+	if sig.Params().Len() == 1 && sig.Results().Len() == 0 {
+		if ptr, ok := sig.Params().At(0).Type().(*types.Pointer); ok {
+			if named, ok := ptr.Elem().(*types.Named); ok {
+				if tn := named.Obj(); tn.Pkg() != nil && tn.Pkg().Path() == runtimePkg && tn.Name() == "Reporter" {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, fmt.Errorf("%s: %s.ValidateStruct must have signature func(r *govader.Reporter)", pkg.Fset.Position(sel.Obj().Pos()), typeName)
+}
+
+// checkRuleFunc checks that fn is a function with the signature of a custom
+// rule, func(value T, args...) error where args have basic types.
+func checkRuleFunc(fd *ast.FuncDecl, fn RuleFunc) error {
+	if fn.Name == "" {
+		return fmt.Errorf("rule %s has no name", fn.Func)
+	}
+	if isBuiltinRule(fn.Name) {
+		return fmt.Errorf("rule %s of %s clashes with a built-in rule", fn.Name, fn.Func)
+	}
+	if fd.Recv != nil || fd.Type.TypeParams != nil {
+		return fmt.Errorf("rule %s: %s must be a non-generic function", fn.Name, fn.Func)
+	}
+	params, results := fn.Sig.Params(), fn.Sig.Results()
+	if params.Len() == 0 || fn.Sig.Variadic() {
+		return fmt.Errorf("rule %s: %s must take the field value as first parameter", fn.Name, fn.Func)
+	}
+	for i := 1; i < params.Len(); i++ {
+		if basic, ok := params.At(i).Type().Underlying().(*types.Basic); !ok || !isValueKind(basic.Kind()) {
+			return fmt.Errorf("rule %s: parameter %s of %s must have a bool, integer, float or string type", fn.Name, params.At(i).Name(), fn.Func)
+		}
+	}
+	if results.Len() != 1 || !types.Identical(results.At(0).Type(), types.Universe.Lookup("error").Type()) {
+		return fmt.Errorf("rule %s: %s must return an error", fn.Name, fn.Func)
+	}
+	return nil
+}
+
+// parseDirective parses space separated key=value attributes of a directive,
+// values may be double quoted.
+func parseDirective(directive string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for directive = strings.TrimSpace(directive); directive != ""; directive = strings.TrimSpace(directive) {
+		key, rest, ok := strings.Cut(directive, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid directive attribute: %s", directive)
+		}
+		value := rest
+		if strings.HasPrefix(rest, "\"") {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid directive attribute %s: %s", key, err)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else if i := strings.IndexAny(rest, " \t"); i != -1 {
+			value, rest = rest[:i], rest[i:]
+		} else {
+			rest = ""
+		}
+		attrs[key] = value
+		directive = rest
+	}
+	return attrs, nil
+}
+
 type Package struct {
 	*packages.Package
 	files []*File
@@ -147,9 +298,14 @@ type File struct {
 	file     *ast.File
 	typeName string
 	values   []StructInfo
+	doc      *ast.CommentGroup // Doc comment of the type declaration being scanned.
 }
 
 func (f *File) scanTypeStruct(n ast.Node) bool {
+	if decl, ok := n.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+		f.doc = decl.Doc
+		return true
+	}
 	typeSpec, ok := n.(*ast.TypeSpec)
 	if !ok {
 		return true
@@ -164,23 +320,47 @@ func (f *File) scanTypeStruct(n ast.Node) bool {
 	value := StructInfo{
 		Name:      structName,
 		FieldList: make([]FieldInfo, 0),
+		Pkg:       f.pkg.Types,
+	}
+	doc := typeSpec.Doc
+	if doc == nil {
+		doc = f.doc
+	}
+	if doc != nil {
+		for _, c := range doc.List {
+			if rule, ok := strings.CutPrefix(c.Text, "//govader:group "); ok {
+				value.GroupRules = append(value.GroupRules, strings.TrimSpace(rule))
+			}
+		}
 	}
 	for _, field := range structType.Fields.List {
 		for _, iden := range field.Names {
-			fieldType := f.pkg.TypesInfo.TypeOf(field.Type)
-			basicType := fieldType.Underlying().(*types.Basic).Kind()
-
 			var tag string
 			if field.Tag != nil {
 				tag = reflect.StructTag(field.Tag.Value[1 : len(field.Tag.Value)-1]).Get("gov")
 			}
+			if iden.Name == "_" {
+				// Tags of blank fields declare group rules, e.g. _ struct{} `gov:"one_of_required=Email,Phone"`.
+				if tag != "" && tag != "-" {
+					value.GroupRules = append(value.GroupRules, strings.Split(tag, ";")...)
+				}
+				continue
+			}
+			value.FieldNames = append(value.FieldNames, iden.Name)
 			if tag == "" || tag == "-" {
 				continue
 			}
+			fieldType := f.pkg.TypesInfo.TypeOf(field.Type)
+			var basicType types.BasicKind // Invalid for fields of non-basic types.
+			if basic, ok := fieldType.Underlying().(*types.Basic); ok {
+				basicType = basic.Kind()
+			}
 			value.FieldList = append(value.FieldList, FieldInfo{
-				Name: iden.Name,
-				Tag:  tag,
-				Type: basicType,
+				Name:   iden.Name,
+				Tag:    tag,
+				Type:   basicType,
+				GoType: fieldType,
+				Pos:    f.pkg.Fset.Position(field.Tag.Pos()),
 			})
 		}
 	}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"net/netip"
+	"strings"
+)
+
+// netTypes maps the network types rules operate on directly to their
+// kind, e.g. net/netip.Addr to addr.
+var netTypes = map[string]string{
+	"net/netip.Addr":   "addr",
+	"net/netip.Prefix": "prefix",
+	"net.IP":           "ip",
+	"*net/url.URL":     "url",
+}
+
+// netType returns the kind of the network type t, or "" if t is none.
+func netType(t types.Type) string {
+	return netTypes[t.String()]
+}
+
+// parseNetRule parses a rule on a netip.Addr, netip.Prefix, net.IP or
+// *url.URL field. Address rules check the unmapped address, so ipv4 holds
+// for ::ffff:10.0.0.1, and empty fields satisfy all rules.
+func parseNetRule(f FieldInfo, ruleName, ruleValue string) (SchemaRule, error) {
+	kind := netType(f.typ())
+	x := "u." + f.Name
+	rule := SchemaRule{
+		Name:   ruleName,
+		Type:   ruleNet,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: ruleValue},
+	}
+	fail := func(format string, args ...any) (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: %s", f.Pos, f.Name, ruleName, fmt.Sprintf(format, args...))
+	}
+	if takesValue := ruleName == "in_prefix" || ruleName == "scheme" || ruleName == "host_in"; takesValue != (ruleValue != "") {
+		if takesValue {
+			return fail("needs a value")
+		}
+		return fail("takes no value")
+	}
+
+	if kind == "url" {
+		var expr string
+		switch ruleName {
+		case "scheme":
+			expr = fmt.Sprintf("slices.Contains(%#v, %s.Scheme)", strings.Split(strings.ToLower(ruleValue), ","), x)
+		case "host_in":
+			expr = fmt.Sprintf("slices.Contains(%#v, strings.ToLower(%s.Hostname()))", strings.Split(strings.ToLower(ruleValue), ","), x)
+		case "no_userinfo":
+			expr = fmt.Sprintf("%s.User == nil", x)
+		default:
+			return fail("not supported on field of type %s", f.typ())
+		}
+		rule.Expr = fmt.Sprintf("%s == nil || %s", x, expr)
+		return rule, nil
+	}
+
+	addr, bits, empty := x+".Unmap()", x+".Unmap().BitLen()", "!"+x+".IsValid()"
+	switch kind {
+	case "prefix":
+		addr, bits = x+".Addr()", x+".Bits()"
+	case "ip":
+		addr, bits, empty = "govader.IPAddr("+x+")", "govader.IPAddr("+x+").BitLen()", "len("+x+") == 0"
+	}
+	var expr string
+	switch ruleName {
+	case "ipv4":
+		expr = addr + ".Is4()"
+	case "ipv6":
+		expr = addr + ".Is6()"
+	case "private":
+		expr = addr + ".IsPrivate()"
+	case "public":
+		expr = fmt.Sprintf("%s.IsGlobalUnicast() && !%s.IsPrivate()", addr, addr)
+	case "loopback":
+		expr = addr + ".IsLoopback()"
+	case "in_prefix":
+		prefixes := strings.Split(ruleValue, ",")
+		for _, p := range prefixes {
+			if _, err := netip.ParsePrefix(p); err != nil {
+				return fail("%s", err)
+			}
+		}
+		args := []string{addr, bits}
+		for _, p := range prefixes {
+			args = append(args, fmt.Sprintf("%q", p))
+		}
+		expr = fmt.Sprintf("govader.InPrefix(%s)", strings.Join(args, ", "))
+	default:
+		return fail("not supported on field of type %s", f.typ())
+	}
+	rule.Expr = fmt.Sprintf("%s || %s", empty, expr)
+	return rule, nil
+}
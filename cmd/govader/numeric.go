@@ -0,0 +1,354 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// parseNumericRule parses a numeric rule. Tag values are parsed exactly and
+// bounds which do not fit the field type are decided at generation time,
+// e.g. lte=300 always holds for an uint8 field and gt=300 never does.
+func parseNumericRule(f FieldInfo, ruleName, ruleValue string) (SchemaRule, error) {
+	basic, ok := f.typ().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsNumeric == 0 || basic.Info()&types.IsComplex != 0 {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s needs a numeric field, got %s", f.Pos, f.Name, ruleName, f.typ())
+	}
+	isFloat := basic.Info()&types.IsFloat != 0
+	x := "u." + f.Name
+	rule := SchemaRule{
+		Name:   ruleName,
+		Type:   ruleNumeric,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: ruleValue},
+	}
+	fail := func(format string, args ...any) (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: %s", f.Pos, f.Name, ruleName, fmt.Sprintf(format, args...))
+	}
+
+	switch ruleName {
+	case "positive", "negative", "non_zero":
+		if ruleValue != "" {
+			return fail("takes no value")
+		}
+		op := map[string]string{"positive": "gt", "negative": "lt", "non_zero": "ne"}[ruleName]
+		expr, err := compareNumber(basic, x, op, big.NewRat(0, 1))
+		if err != nil {
+			return fail("%s", err)
+		}
+		rule.Expr = expr
+	case "gt", "gte", "lt", "lte":
+		c, err := parseNumber(ruleValue)
+		if err != nil {
+			return fail("%s", err)
+		}
+		if rule.Expr, err = compareNumber(basic, x, ruleName, c); err != nil {
+			return fail("%s", err)
+		}
+	case "multiple_of":
+		c, err := parseNumber(ruleValue)
+		if err != nil || c.Sign() == 0 {
+			return fail("needs a non-zero number, got %q", ruleValue)
+		}
+		if isFloat {
+			k, _ := c.Float64()
+			k = math.Abs(k)
+			// Allow for the rounding of decimal multiples, e.g. 0.3 of 0.1.
+			rule.Expr = fmt.Sprintf("math.Abs(math.Remainder(float64(%s), %s)) <= %s", x, formatFloat(k), formatFloat(k*1e-9))
+			rule.Imports = []string{"math"}
+			break
+		}
+		if !c.IsInt() {
+			return fail("needs an integer for field of type %s, got %s", f.typ(), ruleValue)
+		}
+		c.Abs(c) // Multiples of -k are the multiples of k.
+		if _, hi := intRange(basic); c.Num().Cmp(hi) > 0 {
+			rule.Expr = fmt.Sprintf("%s == 0", x) // Only zero is a multiple in range.
+		} else {
+			rule.Expr = fmt.Sprintf("%s%%%s == 0", x, c.Num())
+		}
+	case "digits", "digits_between":
+		if isFloat {
+			return fail("needs an integer field, got %s", f.typ())
+		}
+		bounds := strings.Split(ruleValue, ",")
+		if len(bounds) != map[string]int{"digits": 1, "digits_between": 2}[ruleName] {
+			return fail("invalid value %q", ruleValue)
+		}
+		for _, b := range bounds {
+			if n, err := strconv.Atoi(b); err != nil || n < 1 {
+				return fail("needs a number of digits, got %q", b)
+			}
+		}
+		digits := fmt.Sprintf("len(strconv.FormatUint(uint64(%s), 10))", x)
+		if basic.Info()&types.IsUnsigned == 0 {
+			digits = fmt.Sprintf("len(strings.TrimPrefix(strconv.FormatInt(int64(%s), 10), \"-\"))", x)
+		}
+		if ruleName == "digits" {
+			rule.Expr = fmt.Sprintf("%s == %s", digits, bounds[0])
+		} else {
+			rule.Expr = fmt.Sprintf("%s >= %s && %s <= %s", digits, bounds[0], digits, bounds[1])
+			rule.Cond1.Value, rule.Cond2 = bounds[0], &Value{Type: types.String, Value: bounds[1]}
+		}
+		rule.Imports = []string{"strconv"}
+	case "decimal_places":
+		if !isFloat {
+			return fail("needs a float field, got %s", f.typ())
+		}
+		if n, err := strconv.Atoi(ruleValue); err != nil || n < 0 {
+			return fail("needs a number of decimal places, got %q", ruleValue)
+		}
+		rule.Expr = fmt.Sprintf("_Gov_DecimalPlaces(strconv.FormatFloat(float64(%s), 'f', -1, %d)) <= %s", x, sizeOf(basic)*8, ruleValue)
+		rule.Imports = []string{"strconv"}
+	}
+	return rule, nil
+}
+
+// bigNumberOps maps the rules on arbitrary-precision numbers to the
+// operator comparing the field with the rule value, or with zero.
+var bigNumberOps = map[string]string{
+	"min": ">=", "max": "<=", "gt": ">", "gte": ">=", "lt": "<", "lte": "<=",
+	"positive": ">", "negative": "<", "non_zero": "!=",
+}
+
+// isBigNumber reports whether t is an arbitrary-precision number type,
+// e.g. *big.Int, *big.Rat, *big.Float or a decimal money type, that is a
+// non-basic type implementing govader.Decimal[t].
+func isBigNumber(t types.Type) bool {
+	if _, ok := t.Underlying().(*types.Basic); ok {
+		return false
+	}
+	mset := types.NewMethodSet(t)
+	method := func(name string) *types.Signature {
+		if sel := mset.Lookup(nil, name); sel != nil && sel.Obj().Exported() {
+			return sel.Type().(*types.Signature)
+		}
+		return nil
+	}
+	cmp, str := method("Cmp"), method("String")
+	return cmp != nil && str != nil &&
+		cmp.Params().Len() == 1 && types.Identical(cmp.Params().At(0).Type(), t) &&
+		cmp.Results().Len() == 1 && types.Identical(cmp.Results().At(0).Type(), types.Typ[types.Int]) &&
+		str.Params().Len() == 0 && str.Results().Len() == 1 && types.Identical(str.Results().At(0).Type(), types.Typ[types.String])
+}
+
+// parseBigNumberRule parses a rule on an arbitrary-precision number field.
+// Tag values are checked at generation time and compared exactly with the
+// field at run time, nil fields satisfy the rules.
+func parseBigNumberRule(f FieldInfo, ruleName, ruleValue string) (SchemaRule, error) {
+	x := "u." + f.Name
+	rule := SchemaRule{
+		Name:   ruleName,
+		Type:   ruleNumeric,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: ruleValue},
+	}
+	fail := func(format string, args ...any) (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: %s", f.Pos, f.Name, ruleName, fmt.Sprintf(format, args...))
+	}
+
+	switch ruleName {
+	case "positive", "negative", "non_zero":
+		if ruleValue != "" {
+			return fail("takes no value")
+		}
+		rule.Expr = fmt.Sprintf("govader.Compare(%s, %q, \"0\")", x, bigNumberOps[ruleName])
+	case "between":
+		min, max, ok := strings.Cut(ruleValue, ",")
+		if !ok {
+			return fail("needs min,max, got %q", ruleValue)
+		}
+		lo, err := parseNumber(min)
+		if err != nil {
+			return fail("%s", err)
+		}
+		hi, err := parseNumber(max)
+		if err != nil {
+			return fail("%s", err)
+		}
+		if lo.Cmp(hi) > 0 {
+			return fail("min %s is greater than max %s", min, max)
+		}
+		rule.Expr = fmt.Sprintf("govader.Compare(%s, \">=\", %q) && govader.Compare(%s, \"<=\", %q)", x, min, x, max)
+		rule.Cond1.Value, rule.Cond2 = min, &Value{Type: types.String, Value: max}
+	case "decimal_places":
+		if n, err := strconv.Atoi(ruleValue); err != nil || n < 0 {
+			return fail("needs a number of decimal places, got %q", ruleValue)
+		}
+		rule.Expr = fmt.Sprintf("govader.DecimalPlaces(%s, %s)", x, ruleValue)
+	default:
+		op, ok := bigNumberOps[ruleName]
+		if !ok {
+			return fail("not supported on field of type %s", f.typ())
+		}
+		if _, err := parseNumber(ruleValue); err != nil {
+			return fail("%s", err)
+		}
+		rule.Expr = fmt.Sprintf("govader.Compare(%s, %q, %q)", x, op, ruleValue)
+	}
+	return rule, nil
+}
+
+// compareNumber returns the expression comparing x of type t with the
+// constant c, op is one of gt, gte, lt, lte and ne. Integer comparisons are
+// rewritten to inclusive bounds within the range of t, e.g. x > 2.5 becomes
+// x >= 3, it returns "true" if every value satisfies the comparison and an
+// error if none does.
+func compareNumber(t *types.Basic, x, op string, c *big.Rat) (string, error) {
+	if t.Info()&types.IsFloat != 0 {
+		f, _ := c.Float64()
+		if math.IsInf(f, 0) {
+			return "", fmt.Errorf("%s overflows float64", c.RatString())
+		}
+		sym := map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "ne": "!="}[op]
+		return fmt.Sprintf("float64(%s) %s %s", x, sym, formatFloat(f)), nil
+	}
+	lo, hi := intRange(t)
+	switch op {
+	case "ne":
+		if !c.IsInt() || c.Num().Cmp(lo) < 0 || c.Num().Cmp(hi) > 0 {
+			return "true", nil
+		}
+		return fmt.Sprintf("%s != %s", x, c.Num()), nil
+	case "gt", "gte":
+		k := ceil(c)
+		if op == "gt" {
+			k = new(big.Int).Add(floor(c), big.NewInt(1))
+		}
+		switch {
+		case k.Cmp(lo) <= 0:
+			return "true", nil
+		case k.Cmp(hi) > 0:
+			return "", fmt.Errorf("no value of type %s is greater than %s", t, c.RatString())
+		}
+		return fmt.Sprintf("%s >= %s", x, k), nil
+	default: // lt, lte
+		k := floor(c)
+		if op == "lt" {
+			k = new(big.Int).Sub(ceil(c), big.NewInt(1))
+		}
+		switch {
+		case k.Cmp(hi) >= 0:
+			return "true", nil
+		case k.Cmp(lo) < 0:
+			return "", fmt.Errorf("no value of type %s is less than %s", t, c.RatString())
+		}
+		return fmt.Sprintf("%s <= %s", x, k), nil
+	}
+}
+
+// parseBoundRule parses a min, max or between rule on a numeric field.
+// Bounds on integer fields are rounded inwards, lower bounds up and upper
+// bounds down, and clamped to the range of the field type, e.g. max=300 on
+// an uint8 field becomes max=255. Bounds no value satisfies are an error.
+func parseBoundRule(f FieldInfo, ruleName, ruleValue string) (SchemaRule, error) {
+	if !isValueKind(f.Type) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: unsupported type %s for rule %s", f.Pos, f.Name, f.typ(), ruleName)
+	}
+	if ruleName != "between" {
+		bound, err := clampBound(f, ruleName, ruleValue, ruleName == "max")
+		if err != nil {
+			return SchemaRule{}, err
+		}
+		return parseValueConstraintRule(f, ruleName, bound), nil
+	}
+	min, max, ok := strings.Cut(ruleValue, ",")
+	if !ok {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule between needs min,max, got %q", f.Pos, f.Name, ruleValue)
+	}
+	lo, err := clampBound(f, ruleName, min, false)
+	if err != nil {
+		return SchemaRule{}, err
+	}
+	hi, err := clampBound(f, ruleName, max, true)
+	if err != nil {
+		return SchemaRule{}, err
+	}
+	if a, b := mustParseNumber(lo), mustParseNumber(hi); a.Cmp(b) > 0 {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule between=%s is never satisfied by type %s", f.Pos, f.Name, ruleValue, f.typ())
+	}
+	return parseRangeRule(f, ruleName, lo+","+hi), nil
+}
+
+// clampBound rounds and clamps a bound of a min, max or between rule, see
+// parseBoundRule.
+func clampBound(f FieldInfo, ruleName, value string, upper bool) (string, error) {
+	basic := f.typ().Underlying().(*types.Basic)
+	c, err := parseNumber(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: field %s: rule %s: %s", f.Pos, f.Name, ruleName, err)
+	}
+	if basic.Info()&types.IsFloat != 0 {
+		v, _ := c.Float64()
+		if math.IsInf(v, 0) {
+			return "", fmt.Errorf("%s: field %s: rule %s: %s overflows float64", f.Pos, f.Name, ruleName, value)
+		}
+		// Fractions and hex numbers are written as the nearest float64.
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	}
+	lo, hi := intRange(basic)
+	k := ceil(c)
+	if upper {
+		k = floor(c)
+	}
+	switch {
+	case upper && k.Cmp(lo) < 0, !upper && k.Cmp(hi) > 0:
+		return "", fmt.Errorf("%s: field %s: rule %s=%s is never satisfied by type %s", f.Pos, f.Name, ruleName, value, f.typ())
+	case upper && k.Cmp(hi) > 0:
+		k = hi
+	case !upper && k.Cmp(lo) < 0:
+		k = lo
+	}
+	return k.String(), nil
+}
+
+// parseNumber parses a decimal, exponent or fraction number exactly.
+func parseNumber(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return r, nil
+}
+
+// mustParseNumber is like parseNumber but panics if s is not a number.
+func mustParseNumber(s string) *big.Rat {
+	r, err := parseNumber(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// intRange returns the smallest and largest value of the integer type t.
+func intRange(t *types.Basic) (lo, hi *big.Int) {
+	bits := uint(sizeOf(t) * 8)
+	one := big.NewInt(1)
+	if t.Info()&types.IsUnsigned != 0 {
+		return new(big.Int), new(big.Int).Sub(new(big.Int).Lsh(one, bits), one)
+	}
+	hi = new(big.Int).Sub(new(big.Int).Lsh(one, bits-1), one)
+	return new(big.Int).Neg(new(big.Int).Add(hi, one)), hi
+}
+
+// floor returns the largest integer less than or equal to r.
+func floor(r *big.Rat) *big.Int {
+	return new(big.Int).Div(r.Num(), r.Denom()) // Euclidean division, the denominator is positive.
+}
+
+// ceil returns the smallest integer greater than or equal to r.
+func ceil(r *big.Rat) *big.Int {
+	return new(big.Int).Neg(floor(new(big.Rat).Neg(r)))
+}
+
+// formatFloat formats f as a Go floating-point literal.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
@@ -1,30 +1,95 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/printer"
+	"go/token"
 	"go/types"
 	"maps"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cast"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 type StructInfo struct {
-	Name      string      // Name of the struct.
-	FieldList []FieldInfo // List of fields in the struct.
+	Name       string      // Name of the struct.
+	FieldList  []FieldInfo // List of fields in the struct.
+	FieldNames []string    // Names of all fields in the struct, including fields without rules.
+	Hook       bool        // Struct has a ValidateStruct(r *govader.Reporter) method.
+	GroupRules []string    // Rules on groups of fields, e.g. one_of_required=Email,Phone.
+	Pkg        *types.Package
 }
 
 type FieldInfo struct {
-	Name string          // Name of the field.
-	Tag  string          // Validation tag. e.g `required;min=1`
-	Type types.BasicKind // Type of the field.
+	Name   string          // Name of the field.
+	Tag    string          // Validation tag. e.g `required;min=1`
+	Type   types.BasicKind // Type of the field.
+	GoType types.Type      // Declared type of the field, may be nil.
+	Pos    token.Position  // Position of the field tag.
+}
+
+// fieldType returns the type of the named field of the struct,
+// or nil if the struct has no such field.
+func (s StructInfo) fieldType(name string) types.Type {
+	if s.Pkg != nil {
+		if obj := s.Pkg.Scope().Lookup(s.Name); obj != nil {
+			if st, ok := obj.Type().Underlying().(*types.Struct); ok {
+				for i := range st.NumFields() {
+					if st.Field(i).Name() == name {
+						return st.Field(i).Type()
+					}
+				}
+			}
+			return nil
+		}
+	}
+	for _, f := range s.FieldList {
+		if f.Name == name {
+			return f.typ()
+		}
+	}
+	return nil
+}
+
+// typ returns the declared type of the field.
+func (f FieldInfo) typ() types.Type {
+	if f.GoType != nil {
+		return f.GoType
+	}
+	return types.Typ[f.Type]
+}
+
+// RuleFunc is a custom rule implemented by a Go function of the package,
+// declared with a //govader:rule name=<rule> directive.
+type RuleFunc struct {
+	Name     string            // Name of the rule.
+	Func     string            // Name of the function.
+	Messages map[string]string // Error messages of the rule keyed by locale, "" for the default, the function error is used if empty.
+	Sig      *types.Signature  // Signature of the function, e.g. func(value string, prefix string) error.
 }
 
 type Schema struct {
 	Type       StructInfo
 	Rules      []SchemaRule
 	Validators []string
+	Modifiers  map[string][]string // Field modifiers keyed by field name, e.g. bail.
+	Sanitizers map[string][]string // Field sanitizers keyed by field name in tag order, e.g. trim.
+	Defaults   map[string]*Value   // Field default values keyed by field name.
+}
+
+// HasModifier reports whether the field has the given modifier.
+func (s Schema) HasModifier(field, modifier string) bool {
+	return slices.Contains(s.Modifiers[field], modifier)
 }
 
 type Value struct {
@@ -44,62 +109,215 @@ func (v Value) TypeName() string {
 		return "string"
 	case types.Bool:
 		return "bool"
+	case types.Invalid:
+		return "any" // Presence of a field of non-basic type.
 	default:
 		panic(fmt.Sprintf("invalid field value type %v", v.Type))
 	}
 }
 
+// Literal returns the value as a Go literal.
+func (v Value) Literal() string {
+	if s, ok := v.Value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v.Value)
+}
+
 type ruleType uint8
 
 const (
-	rulePresence = iota
+	rulePresence ruleType = iota
 	ruleValueConstraint
 	ruleConditional
 	ruleRange
+	ruleService
+	ruleCustom
+	ruleExpr
+	ruleCompare
+	ruleGroup
+	ruleIn
+	ruleNumeric
+	ruleNet
+	ruleCollection
+	rulePassword
 )
 
 type SchemaRule struct {
-	Name   string
-	Type   ruleType
-	Field1 string
-	Field2 string
-	Cond1  *Value
-	Cond2  *Value
+	Name    string
+	Type    ruleType
+	Field1  string
+	Field2  string
+	Cond1   *Value
+	Cond2   *Value
+	Groups  []string // Validation groups of the rule, rule applies always if empty.
+	Func    string   // Function of a custom rule.
+	Args    []*Value // Arguments of a custom rule function, or the values of an in rule.
+	Expr    string   // Go expression of an expr rule, with fields qualified by u.
+	Deps    []string // Other fields the rule depends on, or the fields of a group rule.
+	Imports []string // Packages used by Expr besides the base imports.
 }
 
 func (r SchemaRule) FuncName() string {
-	if r.Type == ruleConditional {
+	if r.Type == ruleConditional || r.Type == ruleCustom || r.Type == ruleExpr || r.Type == ruleCompare || r.Type == ruleGroup || r.Type == ruleIn || r.Type == ruleNumeric || r.Type == ruleNet || r.Type == ruleCollection || r.Type == rulePassword {
 		return fmt.Sprintf("_Gov_%s", r.Name)
 	}
 	return fmt.Sprintf("_Gov_%s_%s", r.Name, r.Cond1.TypeName())
 }
 
 var (
+	// presenceRules contains list of rules checking the field is set.
+	presenceRules = []string{"required", "prohibited"}
+
+	// conditionalRules contains list of rules depending on other fields,
+	// written rule:Field or rule:Field=value.
+	conditionalRules = []string{
+		"required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all", "prohibited_if", "prohibited_unless",
+		"same", "different", "exclude_if",
+	}
+
+	// valConstRules contains list of value constraint rules taking a value.
+	valConstRules = []string{"min", "max", "size", "regexp"}
+
+	// rangeRules contains list of rules taking a min,max range.
+	rangeRules = []string{"between"}
+
 	// presetValConstRules contains list of predefined value constraint rules.
-	presetValConstRules = []string{"email"}
+	presetValConstRules = []string{
+		"email", "url", "http_url", "uuid", "ulid", "ip", "ipv4", "ipv6",
+		"cidr", "hostname", "fqdn", "mac", "port",
+		"alpha", "alpha_num", "alpha_dash", "numeric", "ascii", "printable",
+		"lowercase", "uppercase", "no_whitespace",
+		"starts_with", "ends_with", "contains", "excludes",
+		"min_bytes", "max_bytes", "min_graphemes", "max_graphemes",
+		"luhn", "iban", "isbn10", "isbn13", "e164",
+		"iso3166_alpha2", "iso3166_alpha3", "iso4217", "bcp47",
+	}
+
+	// lengthRules contains list of rules limiting the length of a string field.
+	lengthRules = []string{"min", "max", "size", "between", "min_bytes", "max_bytes", "min_graphemes", "max_graphemes"}
+
+	// numericRules contains list of rules on numeric fields, they compile to
+	// a comparison on the declared field type.
+	numericRules = []string{
+		"gt", "gte", "lt", "lte", "positive", "negative", "non_zero",
+		"multiple_of", "digits", "digits_between", "decimal_places",
+	}
+
+	// netRules contains list of rules on netip.Addr, netip.Prefix, net.IP
+	// and *url.URL fields.
+	netRules = []string{"ipv4", "ipv6", "private", "public", "loopback", "in_prefix", "scheme", "host_in", "no_userinfo"}
+
+	// collectionRules contains list of rules on the elements of a slice
+	// field, sum rules are written sum(Field)=value, sum(Field)<=value or
+	// sum(Field)>=value.
+	collectionRules = []string{"distinct", "unique", "contains", "sum"}
+
+	// substringRules contains list of predefined rules taking a substring.
+	substringRules = []string{"starts_with", "ends_with", "contains", "excludes"}
+
+	// emailOptions contains list of options of the email rule, domain lists
+	// are separated by '|', e.g. email=strict,deny_domains:a.com|b.com.
+	emailOptions = []string{"strict", "allow_idn", "allow_domains", "deny_domains", "mx"}
+
+	// serviceRules contains list of rules backed by an injected service.
+	serviceRules = []string{"unique"}
+
+	// compareRules maps rules comparing two fields to their operator.
+	compareRules = map[string]string{
+		"gt_field":  ">",
+		"gte_field": ">=",
+		"lt_field":  "<",
+		"lte_field": "<=",
+	}
+
+	// groupRules contains list of rules on groups of fields.
+	groupRules = []string{"one_of_required", "exactly_one_of", "mutually_exclusive"}
+
+	// ruleModifiers contains list of modifiers which are not rules themselves
+	// but change how the other rules of a field are evaluated.
+	ruleModifiers = []string{"bail", "sometimes", "nullable"}
+
+	// sanitizers contains list of string transformations applied by the
+	// generated Normalize function before validation.
+	sanitizers = []string{"trim", "lower", "upper", "nfc", "nfkc", "collapse_spaces", "strip_control"}
+
+	// ruleGroupsRe matches the validation groups suffix of a rule, e.g.
+	// required@create,update, an escaped \@ belongs to the rule value, e.g.
+	// ends_with=\@acme.
+	ruleGroupsRe = regexp.MustCompile(`(?:^|[^\\])(@([A-Za-z_]\w*(?:,[A-Za-z_]\w*)*))$`)
 )
 
-func parseSchema(info []StructInfo) ([]Schema, error) {
+func parseSchema(info []StructInfo, funcs []RuleFunc) ([]Schema, error) {
 	schemas := make([]Schema, 0, len(info))
 	uniqRuleSet := make(map[string]struct{})
 
 	for _, stct := range info {
 		rules := make([]SchemaRule, 0, 10)
+		modifiers := make(map[string][]string)
+		sanitized := make(map[string][]string)
+		defaults := make(map[string]*Value)
 		for _, field := range stct.FieldList {
 			ruleset := strings.Split(field.Tag, ";")
+			var fieldRules []SchemaRule
 			for _, rulestr := range ruleset {
-				rule, err := parseRule(field, rulestr)
+				// Defaults are parsed before groups as values may contain '@'.
+				if value, ok := strings.CutPrefix(rulestr, "default="); ok {
+					def, err := parseDefault(field, value)
+					if err != nil {
+						return nil, err
+					}
+					defaults[field.Name] = def
+					continue
+				}
+				rulestr, groups := parseRuleGroups(rulestr)
+				if slices.Contains(sanitizers, rulestr) {
+					if err := checkSanitizer(stct, field, rulestr, groups); err != nil {
+						return nil, err
+					}
+					sanitized[field.Name] = append(sanitized[field.Name], rulestr)
+					continue
+				}
+				if slices.Contains(ruleModifiers, rulestr) {
+					if len(groups) > 0 {
+						return nil, fmt.Errorf("modifier %s of field %s cannot have groups", rulestr, field.Name)
+					}
+					modifiers[field.Name] = append(modifiers[field.Name], rulestr)
+					continue
+				}
+				rule, err := parseRule(stct, field, rulestr, funcs)
 				if err != nil {
 					return nil, err
 				}
-				rules = append(rules, rule)
+				rule.Groups = groups
+				fieldRules = append(fieldRules, rule)
 				uniqRuleSet[rule.Name] = struct{}{}
 			}
+			if def := defaults[field.Name]; def != nil {
+				if err := checkDefault(field, def, fieldRules); err != nil {
+					return nil, err
+				}
+			}
+			rules = append(rules, fieldRules...)
+		}
+		for _, rulestr := range stct.GroupRules {
+			rulestr, groups := parseRuleGroups(rulestr)
+			rule, err := parseGroupRule(stct, rulestr)
+			if err != nil {
+				return nil, err
+			}
+			rule.Groups = groups
+			rules = append(rules, rule)
+			uniqRuleSet[rule.Name] = struct{}{}
 		}
 		schema := Schema{
 			Type:       stct,
 			Rules:      rules,
 			Validators: make([]string, 0, len(uniqRuleSet)),
+			Modifiers:  modifiers,
+			Sanitizers: sanitized,
+			Defaults:   defaults,
 		}
 		for k := range maps.Keys(uniqRuleSet) {
 			schema.Validators = append(schema.Validators, k)
@@ -110,9 +328,104 @@ func parseSchema(info []StructInfo) ([]Schema, error) {
 	return schemas, nil
 }
 
-func parseRule(f FieldInfo, rawRule string) (SchemaRule, error) {
+// checkSanitizer checks that the sanitizer is applied without groups to a
+// string field whose type can be named in the generated code.
+func checkSanitizer(stct StructInfo, f FieldInfo, sanitizer string, groups []string) error {
+	if len(groups) > 0 {
+		return fmt.Errorf("sanitizer %s of field %s cannot have groups", sanitizer, f.Name)
+	}
+	if f.Type != types.String {
+		return fmt.Errorf("%s: field %s: sanitizer %s needs a string field, got %s", f.Pos, f.Name, sanitizer, f.typ())
+	}
+	if named, ok := f.typ().(*types.Named); ok && named.Obj().Pkg() != stct.Pkg {
+		return fmt.Errorf("%s: field %s: sanitizer %s needs a string type of package %s, got %s", f.Pos, f.Name, sanitizer, stct.Pkg.Name(), f.typ())
+	}
+	return nil
+}
+
+// parseRuleGroups splits the validation groups suffix from the rule and
+// unescapes the '@' of the rule value.
+func parseRuleGroups(rawRule string) (string, []string) {
+	var groups []string
+	if m := ruleGroupsRe.FindStringSubmatchIndex(rawRule); m != nil {
+		rawRule, groups = rawRule[:m[2]], strings.Split(rawRule[m[4]:m[5]], ",")
+	}
+	return strings.ReplaceAll(rawRule, `\@`, "@"), groups
+}
+
+// isBuiltinRule reports whether name is taken by a built-in rule, modifier,
+// sanitizer or message.
+func isBuiltinRule(name string) bool {
+	if _, ok := compareRules[name]; ok {
+		return true
+	}
+	if _, ok := LoadLocale("en")[name]; ok {
+		return true
+	}
+	for _, names := range [][]string{
+		presenceRules, conditionalRules, valConstRules, rangeRules, presetValConstRules,
+		numericRules, netRules, collectionRules, serviceRules, groupRules, ruleModifiers, sanitizers,
+		{"expr", "enum", "in", "not_in", "password", "default"},
+	} {
+		if slices.Contains(names, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRule(stct StructInfo, f FieldInfo, rawRule string, funcs []RuleFunc) (SchemaRule, error) {
+	name, value, _ := strings.Cut(rawRule, "=")
+	if i := slices.IndexFunc(funcs, func(fn RuleFunc) bool { return fn.Name == name }); i != -1 {
+		return parseCustomRule(f, funcs[i], value)
+	}
+	if name == "expr" {
+		return parseExprRule(stct, f, value)
+	}
+	if isBigNumber(f.typ()) && (slices.Contains(numericRules, name) || name == "min" || name == "max" || name == "between") {
+		return parseBigNumberRule(f, name, value)
+	}
+	if _, ok := f.typ().Underlying().(*types.Slice); ok && (slices.Contains(collectionRules, name) || strings.HasPrefix(name, "sum(")) {
+		return parseCollectionRule(stct, f, rawRule)
+	}
+	if name == "password" {
+		return parsePasswordRule(stct, f, value)
+	}
+	if netType(f.typ()) != "" && slices.Contains(netRules, name) {
+		return parseNetRule(f, name, value)
+	}
+	if f.Type == types.String && slices.Contains(lengthRules, name) {
+		for _, n := range strings.Split(value, ",") {
+			if v, err := strconv.Atoi(n); err != nil || v < 0 {
+				return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s needs a length, got %q", f.Pos, f.Name, name, value)
+			}
+		}
+	}
+	if slices.Contains(numericRules, name) {
+		return parseNumericRule(f, name, value)
+	}
+	if basic, ok := f.typ().Underlying().(*types.Basic); ok && basic.Info()&(types.IsInteger|types.IsFloat) != 0 &&
+		(name == "min" || name == "max" || name == "between") {
+		return parseBoundRule(f, name, value)
+	}
+	if slices.Contains(presetValConstRules, name) {
+		if err := checkPresetRule(f, name, value); err != nil {
+			return SchemaRule{}, err
+		}
+		return parseValueConstraintRule(f, name, value), nil
+	}
+	if name == "enum" {
+		return parseEnumRule(f)
+	}
+	if name == "in" || name == "not_in" {
+		return parseInRule(f, name, strings.Split(value, ","))
+	}
+	if name, field2, ok := strings.Cut(rawRule, ":"); ok && compareRules[name] != "" {
+		return parseCompareRule(stct, f, name, field2)
+	}
+
 	seprator := "=" // rule is either presence or value constraint or range.
-	if strings.IndexRune(rawRule, ':') != -1 {
+	if i := strings.IndexRune(rawRule, ':'); i != -1 && !strings.ContainsRune(rawRule[:i], '=') {
 		seprator = ":" // rule is conditional.
 	}
 
@@ -121,25 +434,72 @@ func parseRule(f FieldInfo, rawRule string) (SchemaRule, error) {
 		return SchemaRule{}, fmt.Errorf("invalid rule format: %v", rawRule)
 	}
 
-	var rule SchemaRule
-	if len(kv) == 1 /* Presense rule */ {
-		if slices.Contains(presetValConstRules, kv[0]) {
-			rule = parseValueConstraintRule(f, kv[0], "")
-		} else {
-			rule = parsePresenceRule(f, kv[0])
+	var (
+		rule SchemaRule
+		err  error
+	)
+	if slices.Contains(serviceRules, kv[0]) /* Service rule */ {
+		if len(kv) == 1 || !strings.Contains(kv[1], ".") {
+			return SchemaRule{}, fmt.Errorf("invalid rule format: %v, expected %s=table.column", rawRule, kv[0])
+		}
+		rule = parseServiceRule(f, kv[0], kv[1])
+	} else if len(kv) == 1 && slices.Contains(presenceRules, kv[0]) /* Presense rule */ {
+		rule = parsePresenceRule(f, kv[0])
+	} else if seprator == ":" && slices.Contains(conditionalRules, kv[0]) /* Conditional rule */ {
+		if rule, err = parseConditionalRule(stct, f, kv[0], kv[1]); err != nil {
+			return SchemaRule{}, err
 		}
-	} else if strings.Contains(kv[1], ",") /* range */ {
+	} else if !isValueKind(f.Type) && (slices.Contains(rangeRules, kv[0]) || slices.Contains(valConstRules, kv[0])) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s not supported on type %s", f.Pos, f.Name, kv[0], f.typ())
+	} else if len(kv) == 2 && slices.Contains(rangeRules, kv[0]) && strings.Contains(kv[1], ",") /* range */ {
 		rule = parseRangeRule(f, kv[0], kv[1])
-	} else if seprator == ":" /* Conditional rule */ {
-		rule = parseConditionalRule(f, kv[0], kv[1])
-	} else /* Value constraint */ {
+	} else if len(kv) == 2 && slices.Contains(valConstRules, kv[0]) /* Value constraint */ {
 		rule = parseValueConstraintRule(f, kv[0], kv[1])
+	} else {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: unknown rule %q", f.Pos, f.Name, kv[0])
 	}
 
 	return rule, nil
 }
 
+// parseGroupRule parses a rule on a group of fields of the struct,
+// e.g. one_of_required=Email,Phone.
+func parseGroupRule(stct StructInfo, rawRule string) (SchemaRule, error) {
+	name, value, _ := strings.Cut(rawRule, "=")
+	if !slices.Contains(groupRules, name) {
+		return SchemaRule{}, fmt.Errorf("invalid group rule %s of struct %s, expected one of %s", name, stct.Name, strings.Join(groupRules, ", "))
+	}
+	fields := strings.Split(value, ",")
+	if len(fields) < 2 {
+		return SchemaRule{}, fmt.Errorf("group rule %s of struct %s needs at least two fields", name, stct.Name)
+	}
+	for _, field := range fields {
+		if stct.fieldType(field) == nil {
+			return SchemaRule{}, fmt.Errorf("group rule %s of struct %s: unknown field %s", name, stct.Name, field)
+		}
+	}
+	return SchemaRule{
+		Name: name,
+		Type: ruleGroup,
+		Deps: fields,
+	}, nil
+}
+
+// isValueKind reports whether rule values can hold the basic kind.
+func isValueKind(kind types.BasicKind) bool {
+	return kind != types.Uintptr && types.Typ[kind].Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0
+}
+
 func parsePresenceRule(f FieldInfo, ruleName string) SchemaRule {
+	if !isValueKind(f.Type) {
+		// Fields of other types are checked by reflection.
+		return SchemaRule{
+			Name:   ruleName,
+			Type:   rulePresence,
+			Field1: f.Name,
+			Cond1:  &Value{Type: types.Invalid},
+		}
+	}
 	return SchemaRule{
 		Name:   ruleName,
 		Type:   rulePresence,
@@ -159,31 +519,44 @@ func parseRangeRule(f FieldInfo, ruleName, ruleValue string) SchemaRule {
 	}
 }
 
-func parseConditionalRule(f FieldInfo, ruleName, ruleValue string) SchemaRule {
-	if field2, cond, ok := strings.Cut(ruleValue, "="); ok {
+func parseConditionalRule(stct StructInfo, f FieldInfo, ruleName, ruleValue string) (SchemaRule, error) {
+	field2, cond, hasCond := strings.Cut(ruleValue, "=")
+	for _, name := range strings.Split(field2, ",") {
+		if stct.fieldType(name) == nil {
+			return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s references missing field %s", f.Pos, f.Name, ruleName, name)
+		}
+	}
+	if hasCond {
 		return SchemaRule{
 			Name:   ruleName,
 			Type:   ruleConditional,
 			Field1: f.Name,
 			Field2: field2,
 			Cond1:  parseValue(types.String, cond), // TODO: preset cond type.
-		}
+		}, nil
+	}
+	if strings.HasSuffix(ruleName, "_if") || strings.HasSuffix(ruleName, "_unless") {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s needs a field and values, e.g. %s:Field=value", f.Pos, f.Name, ruleName, ruleName)
 	}
 	return SchemaRule{
 		Name:   ruleName,
 		Type:   ruleConditional,
 		Field1: f.Name,
 		Field2: ruleValue,
-	}
+	}, nil
 }
 
 func parseValueConstraintRule(f FieldInfo, ruleName, ruleValue string) SchemaRule {
 	if slices.Contains(presetValConstRules, ruleName) {
+		cond := &Value{Type: types.String}
+		if ruleValue != "" {
+			cond.Value = ruleValue
+		}
 		return SchemaRule{
 			Name:   ruleName,
 			Type:   ruleValueConstraint,
 			Field1: f.Name,
-			Cond1:  &Value{Type: types.String},
+			Cond1:  cond,
 		}
 	} else if ruleName == "regexp" {
 		// TODO: specify better way to handle special rules
@@ -204,6 +577,326 @@ func parseValueConstraintRule(f FieldInfo, ruleName, ruleValue string) SchemaRul
 	}
 }
 
+// checkPresetRule checks that a predefined format rule is applied to a
+// string field, only email takes options, uuid the version and the
+// substring rules a substring.
+func checkPresetRule(f FieldInfo, ruleName, ruleValue string) error {
+	if f.Type != types.String {
+		return fmt.Errorf("%s: field %s: rule %s needs a string field, got %s", f.Pos, f.Name, ruleName, f.typ())
+	}
+	switch {
+	case ruleName == "email" && ruleValue != "":
+		for _, opt := range strings.Split(ruleValue, ",") {
+			name, _, _ := strings.Cut(opt, ":")
+			if !slices.Contains(emailOptions, name) {
+				return fmt.Errorf("%s: field %s: invalid email option %s, expected one of %s", f.Pos, f.Name, opt, strings.Join(emailOptions, ", "))
+			}
+		}
+	case slices.Contains(substringRules, ruleName), slices.Contains(lengthRules, ruleName):
+		if ruleValue == "" {
+			return fmt.Errorf("%s: field %s: rule %s needs a value", f.Pos, f.Name, ruleName)
+		}
+	case ruleName == "uuid":
+		if v, err := strconv.Atoi(ruleValue); ruleValue != "" && (err != nil || v < 1 || v > 8) {
+			return fmt.Errorf("%s: field %s: invalid uuid version %s, expected 1 to 8", f.Pos, f.Name, ruleValue)
+		}
+	case ruleValue != "":
+		return fmt.Errorf("%s: field %s: rule %s takes no value", f.Pos, f.Name, ruleName)
+	}
+	return nil
+}
+
+// parseCustomRule parses a custom rule and checks the field and the comma
+// separated arguments against the signature of the rule function.
+func parseCustomRule(f FieldInfo, fn RuleFunc, ruleValue string) (SchemaRule, error) {
+	params := fn.Sig.Params()
+	if !types.AssignableTo(f.typ(), params.At(0).Type()) {
+		return SchemaRule{}, fmt.Errorf("rule %s of field %s: cannot use %s as %s value in %s", fn.Name, f.Name, f.typ(), params.At(0).Type(), fn.Func)
+	}
+	var args []string
+	if ruleValue != "" {
+		args = strings.Split(ruleValue, ",")
+	}
+	if len(args) != params.Len()-1 {
+		return SchemaRule{}, fmt.Errorf("rule %s of field %s: %s expects %d arguments, got %d", fn.Name, f.Name, fn.Func, params.Len()-1, len(args))
+	}
+	rule := SchemaRule{
+		Name:   fn.Name,
+		Type:   ruleCustom,
+		Field1: f.Name,
+		Func:   fn.Func,
+	}
+	for i, arg := range args {
+		param := params.At(i + 1)
+		v, err := parseBasicValue(param.Type().Underlying().(*types.Basic), arg)
+		if err != nil {
+			return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: invalid argument %q for parameter %s of type %s", f.Pos, f.Name, fn.Name, arg, param.Name(), param.Type())
+		}
+		rule.Args = append(rule.Args, v)
+	}
+	return rule, nil
+}
+
+// parseExprRule parses a boolean Go expression over the fields of the struct,
+// e.g. End > Start, and type-checks it in the scope of the struct package.
+func parseExprRule(stct StructInfo, f FieldInfo, expr string) (SchemaRule, error) {
+	fail := func(err error) (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: expr %q: %s", f.Pos, f.Name, expr, err)
+	}
+	if stct.Pkg == nil {
+		return fail(errors.New("struct package not loaded"))
+	}
+
+	// Type-check the expression with fields selected from a composite
+	// literal of the struct, e.g. User{}.End > User{}.Start.
+	e, deps, err := qualifyFields(expr, stct.FieldNames, func() ast.Expr {
+		return &ast.CompositeLit{Type: ast.NewIdent(stct.Name)}
+	})
+	if err != nil {
+		return fail(err)
+	}
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	if err := types.CheckExpr(token.NewFileSet(), stct.Pkg, token.NoPos, e, info); err != nil {
+		if terr, ok := err.(types.Error); ok {
+			err = errors.New(terr.Msg) // Drop the position within the expression.
+		}
+		return fail(err)
+	}
+	if basic, ok := info.Types[e].Type.Underlying().(*types.Basic); !ok || basic.Info()&types.IsBoolean == 0 {
+		return fail(fmt.Errorf("expression of type %s is not boolean", info.Types[e].Type))
+	}
+
+	// Generate the expression with fields selected from the struct value u.
+	e, _, _ = qualifyFields(expr, stct.FieldNames, func() ast.Expr {
+		return ast.NewIdent("u")
+	})
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), e); err != nil {
+		return fail(err)
+	}
+	return SchemaRule{
+		Name:   "expr",
+		Type:   ruleExpr,
+		Field1: f.Name,
+		Cond1:  parseValue(types.String, expr),
+		Expr:   buf.String(),
+		Deps:   slices.DeleteFunc(deps, func(dep string) bool { return dep == f.Name }),
+	}, nil
+}
+
+// parseCompareRule parses a rule ordering the field against another field,
+// e.g. gte_field:MinPrice, and generates the typed comparison.
+func parseCompareRule(stct StructInfo, f FieldInfo, ruleName, field2 string) (SchemaRule, error) {
+	t1, t2 := f.typ(), stct.fieldType(field2)
+	if t2 == nil {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s references missing field %s", f.Pos, f.Name, ruleName, field2)
+	}
+	x, y := "u."+f.Name, "u."+field2
+	op := compareRules[ruleName]
+
+	cannotCompare := func() (SchemaRule, error) {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s cannot compare %s with %s of field %s", f.Pos, f.Name, ruleName, t1, t2, field2)
+	}
+
+	var expr string
+	b1, ok1 := t1.Underlying().(*types.Basic)
+	b2, ok2 := t2.Underlying().(*types.Basic)
+	switch {
+	case hasCompareMethod(t1) && types.Identical(t1, t2):
+		expr = fmt.Sprintf("%s.Compare(%s) %s 0", x, y, op) // e.g. time.Time
+	case !ok1 || !ok2 || b1.Info()&types.IsOrdered == 0 || b2.Info()&types.IsOrdered == 0:
+		return cannotCompare()
+	case types.Identical(t1, t2):
+		expr = fmt.Sprintf("%s %s %s", x, op, y)
+	case types.Identical(b1, b2):
+		expr = fmt.Sprintf("%s(%s) %s %s(%s)", b1.Name(), x, op, b1.Name(), y)
+	case b1.Info()&types.IsString == 0 && b2.Info()&types.IsString == 0:
+		// Widen numbers of different kinds to a common type.
+		u1, u2 := b1.Info()&types.IsUnsigned != 0, b2.Info()&types.IsUnsigned != 0
+		switch {
+		case b1.Info()&b2.Info()&types.IsInteger == 0:
+			expr = fmt.Sprintf("float64(%s) %s float64(%s)", x, op, y)
+		case u1 == u2 && u1:
+			expr = fmt.Sprintf("uint64(%s) %s uint64(%s)", x, op, y)
+		case u1 == u2:
+			expr = fmt.Sprintf("int64(%s) %s int64(%s)", x, op, y)
+		default:
+			// A negative signed operand is less than any unsigned one,
+			// otherwise both fit in uint64.
+			signed, less := x, strings.HasPrefix(op, "<")
+			if u1 {
+				signed, less = y, !less
+			}
+			cmp := fmt.Sprintf("uint64(%s) %s uint64(%s)", x, op, y)
+			if less {
+				expr = fmt.Sprintf("(%s < 0 || %s)", signed, cmp)
+			} else {
+				expr = fmt.Sprintf("%s >= 0 && %s", signed, cmp)
+			}
+		}
+	default:
+		return cannotCompare()
+	}
+	return SchemaRule{
+		Name:   ruleName,
+		Type:   ruleCompare,
+		Field1: f.Name,
+		Field2: field2,
+		Expr:   expr,
+		Deps:   []string{field2},
+	}, nil
+}
+
+// parseEnumRule parses an enum rule, the field must have a named type and
+// the values are the constants of that type declared in its package.
+func parseEnumRule(f FieldInfo) (SchemaRule, error) {
+	named, ok := types.Unalias(f.typ()).(*types.Named)
+	if !ok {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule enum needs a named type, got %s", f.Pos, f.Name, f.typ())
+	}
+	var consts []*types.Const
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule enum found no constants of type %s", f.Pos, f.Name, named)
+	}
+	slices.SortFunc(consts, func(a, b *types.Const) int { return cmp.Compare(a.Pos(), b.Pos()) })
+
+	values := make([]string, 0, len(consts))
+	for _, c := range consts {
+		switch v := c.Val(); v.Kind() {
+		case constant.String:
+			values = append(values, constant.StringVal(v))
+		case constant.Float:
+			f, _ := constant.Float64Val(v)
+			values = append(values, strconv.FormatFloat(f, 'g', -1, 64))
+		default:
+			values = append(values, v.ExactString())
+		}
+	}
+	rule, err := parseInRule(f, "in", values)
+	rule.Name = "enum"
+	return rule, err
+}
+
+// parseInRule parses a rule checking the field against a list of values,
+// e.g. in=active,pending, each value must be representable by the field type.
+func parseInRule(f FieldInfo, ruleName string, values []string) (SchemaRule, error) {
+	basic, ok := f.typ().Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsNumeric|types.IsString|types.IsBoolean) == 0 || basic.Info()&types.IsComplex != 0 {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s cannot check values of type %s", f.Pos, f.Name, ruleName, f.typ())
+	}
+	lits, args := make([]string, 0, len(values)), make([]*Value, 0, len(values))
+	for _, v := range values {
+		lit := v
+		if basic.Info()&types.IsString != 0 {
+			lit = strconv.Quote(v)
+		}
+		// Type check the conversion, e.g. int8(300) overflows.
+		if _, err := types.Eval(token.NewFileSet(), nil, token.NoPos, fmt.Sprintf("%s(%s)", basic.Name(), lit)); err != nil {
+			return SchemaRule{}, fmt.Errorf("%s: field %s: rule %s: invalid value %s: %s", f.Pos, f.Name, ruleName, v, err)
+		}
+		lits = append(lits, lit)
+		args = append(args, parseValue(basic.Kind(), v))
+	}
+	expr := fmt.Sprintf("slices.Contains([]%s{%s}, %s(u.%s))", basic.Name(), strings.Join(lits, ", "), basic.Name(), f.Name)
+	if ruleName == "not_in" {
+		expr = "!" + expr
+	}
+	return SchemaRule{
+		Name:   ruleName,
+		Type:   ruleIn,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: strings.Join(values, ", ")},
+		Args:   args,
+		Expr:   expr,
+	}, nil
+}
+
+// hasCompareMethod reports whether t has a method Compare(t) int, e.g. time.Time.
+func hasCompareMethod(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, "Compare")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), t) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Int])
+}
+
+// qualifyFields parses expr and replaces identifiers of fields with selectors
+// on recv, it returns the expression and the referenced fields.
+func qualifyFields(expr string, fields []string, recv func() ast.Expr) (ast.Expr, []string, error) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deps []string
+	e = astutil.Apply(e, func(c *astutil.Cursor) bool {
+		ident, ok := c.Node().(*ast.Ident)
+		if !ok || !slices.Contains(fields, ident.Name) {
+			return true
+		}
+		switch parent := c.Parent().(type) {
+		case *ast.SelectorExpr:
+			if parent.Sel == ident {
+				return true
+			}
+		case *ast.KeyValueExpr:
+			if parent.Key == ident {
+				return true
+			}
+		}
+		if !slices.Contains(deps, ident.Name) {
+			deps = append(deps, ident.Name)
+		}
+		c.Replace(&ast.SelectorExpr{X: recv(), Sel: ast.NewIdent(ident.Name)})
+		return true
+	}, nil).(ast.Expr)
+	return e, deps, nil
+}
+
+func parseServiceRule(f FieldInfo, ruleName, ruleValue string) SchemaRule {
+	return SchemaRule{
+		Name:   ruleName,
+		Type:   ruleService,
+		Field1: f.Name,
+		Cond1:  parseValue(types.String, ruleValue), // Table and column.
+	}
+}
+
+// parseBasicValue parses v as a value of the basic type, unlike parseValue
+// it rejects values which are not valid for the type.
+func parseBasicValue(basic *types.Basic, v string) (*Value, error) {
+	var (
+		value any
+		err   error
+	)
+	switch info := basic.Info(); {
+	case info&types.IsString != 0:
+		value = v
+	case info&types.IsUnsigned != 0:
+		value, err = strconv.ParseUint(v, 10, sizeOf(basic)*8)
+	case info&types.IsInteger != 0:
+		value, err = strconv.ParseInt(v, 10, sizeOf(basic)*8)
+	case info&types.IsFloat != 0:
+		value, err = strconv.ParseFloat(v, sizeOf(basic)*8)
+	case info&types.IsBoolean != 0:
+		value, err = strconv.ParseBool(v)
+	default:
+		err = fmt.Errorf("unsupported type %s", basic)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Value{Value: value, Type: basic.Kind()}, nil
+}
+
 func parseValue(t types.BasicKind, v string) *Value {
 	switch t {
 	case types.String:
@@ -223,6 +916,8 @@ func parseValue(t types.BasicKind, v string) *Value {
 			return &Value{Value: vv, Type: t}
 		}
 		return &Value{Value: float64(0), Type: t}
+	case types.Bool:
+		return &Value{Value: cast.ToBool(v), Type: t}
 	default:
 		panic(fmt.Sprintf("unsupported rule type: %v", t))
 	}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"go/constant"
 	"go/types"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,9 +13,24 @@ import (
 
 func Test__parseSchema(t *testing.T) {
 	t.Parallel()
+	errType := types.Universe.Lookup("error").Type()
+	skuFunc := RuleFunc{
+		Name: "sku",
+		Func: "validateSKU",
+		Sig: types.NewSignatureType(nil, nil, nil,
+			types.NewTuple(
+				types.NewParam(0, nil, "value", types.Typ[types.String]),
+				types.NewParam(0, nil, "prefix", types.Typ[types.String]),
+				types.NewParam(0, nil, "length", types.Typ[types.Int]),
+			),
+			types.NewTuple(types.NewParam(0, nil, "", errType)),
+			false,
+		),
+	}
 	tests := [...]struct {
 		name    string
 		info    []StructInfo
+		funcs   []RuleFunc
 		want    []Schema
 		wantErr bool
 	}{
@@ -94,6 +113,10 @@ func Test__parseSchema(t *testing.T) {
 					FieldList: []FieldInfo{
 						{Name: "ID", Tag: "required_if:Name=John;different:ID2;same:ID3;required_with:ID1", Type: types.String},
 					},
+					Pkg: newTestStruct("User", map[string]types.Type{
+						"ID": types.Typ[types.String], "ID1": types.Typ[types.String], "ID2": types.Typ[types.String],
+						"ID3": types.Typ[types.String], "Name": types.Typ[types.String],
+					}).Pkg,
 				},
 			},
 			want: []Schema{
@@ -108,22 +131,1099 @@ func Test__parseSchema(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse conditional rules with multiple fields and values",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Zip", Tag: "required_if:Country=US,CA;required_with_all:Street,City;exclude_if:Kind=guest", Type: types.String},
+					},
+					Pkg: newTestStruct("User", map[string]types.Type{
+						"Zip": types.Typ[types.String], "Country": types.Typ[types.String], "Street": types.Typ[types.String],
+						"City": types.Typ[types.String], "Kind": types.Typ[types.String],
+					}).Pkg,
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required_if", Type: ruleConditional, Field1: "Zip", Field2: "Country", Cond1: &Value{Value: "US,CA", Type: types.String}},
+						{Name: "required_with_all", Type: ruleConditional, Field1: "Zip", Field2: "Street,City"},
+						{Name: "exclude_if", Type: ruleConditional, Field1: "Zip", Field2: "Kind", Cond1: &Value{Value: "guest", Type: types.String}},
+					},
+					Validators: []string{"required_if", "required_with_all", "exclude_if"},
+				},
+			},
+		},
+		{
+			name: "parse rule modifiers",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "ID", Tag: "bail;required;min=1", Type: types.Int},
+						{Name: "Age", Tag: "sometimes;nullable;max=99", Type: types.Int},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required", Type: rulePresence, Field1: "ID", Cond1: &Value{Type: types.Int, Value: int64(0)}},
+						{Name: "min", Type: ruleValueConstraint, Field1: "ID", Cond1: &Value{Type: types.Int, Value: int64(1)}},
+						{Name: "max", Type: ruleValueConstraint, Field1: "Age", Cond1: &Value{Type: types.Int, Value: int64(99)}},
+					},
+					Validators: []string{"required", "min", "max"},
+					Modifiers:  map[string][]string{"ID": {"bail"}, "Age": {"sometimes", "nullable"}},
+				},
+			},
+		},
+		{
+			name: "parse rule groups",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "ID", Tag: "required@update;prohibited@create", Type: types.Int},
+						{Name: "Age", Tag: "between=1,10@create,update", Type: types.Int},
+						{Name: "Email", Tag: "regexp=^.+@.+$", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required", Type: rulePresence, Field1: "ID", Cond1: &Value{Type: types.Int, Value: int64(0)}, Groups: []string{"update"}},
+						{Name: "prohibited", Type: rulePresence, Field1: "ID", Cond1: &Value{Type: types.Int, Value: int64(0)}, Groups: []string{"create"}},
+						{
+							Name:   "between",
+							Type:   ruleRange,
+							Field1: "Age",
+							Cond1:  &Value{Value: int64(1), Type: types.Int},
+							Cond2:  &Value{Value: int64(10), Type: types.Int},
+							Groups: []string{"create", "update"},
+						},
+						{Name: "regexp", Type: ruleValueConstraint, Field1: "Email", Cond1: &Value{Value: "^.+@.+$", Type: types.String}},
+					},
+					Validators: []string{"required", "prohibited", "between", "regexp"},
+				},
+			},
+		},
+		{
+			name: "parse service rule",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "unique=users.email", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "unique", Type: ruleService, Field1: "Email", Cond1: &Value{Value: "users.email", Type: types.String}},
+					},
+					Validators: []string{"unique"},
+				},
+			},
+		},
+		{
+			name: "parse invalid service rule",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "unique=users", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse custom rule",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "Code", Tag: "sku=AB,6", Type: types.String},
+					},
+				},
+			},
+			funcs: []RuleFunc{skuFunc},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{
+							Name:   "sku",
+							Type:   ruleCustom,
+							Field1: "Code",
+							Func:   "validateSKU",
+							Args:   []*Value{{Value: "AB", Type: types.String}, {Value: int64(6), Type: types.Int}},
+						},
+					},
+					Validators: []string{"sku"},
+				},
+			},
+		},
+		{
+			name: "parse custom rule with mistyped field",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "Code", Tag: "sku=AB,6", Type: types.Int},
+					},
+				},
+			},
+			funcs:   []RuleFunc{skuFunc},
+			wantErr: true,
+		},
+		{
+			name: "parse custom rule with missing arguments",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "Code", Tag: "sku=AB", Type: types.String},
+					},
+				},
+			},
+			funcs:   []RuleFunc{skuFunc},
+			wantErr: true,
+		},
+		{
+			name: "parse custom rule with mistyped argument",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "Code", Tag: "sku=AB,six", Type: types.String},
+					},
+				},
+			},
+			funcs:   []RuleFunc{skuFunc},
+			wantErr: true,
+		},
+		{
+			name: "parse format rules",
+			info: []StructInfo{
+				{
+					Name: "Server",
+					FieldList: []FieldInfo{
+						{Name: "ID", Tag: "uuid=4", Type: types.String},
+						{Name: "Host", Tag: "required;hostname", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "uuid", Type: ruleValueConstraint, Field1: "ID", Cond1: &Value{Value: "4", Type: types.String}},
+						{Name: "required", Type: rulePresence, Field1: "Host", Cond1: &Value{Value: "", Type: types.String}},
+						{Name: "hostname", Type: ruleValueConstraint, Field1: "Host", Cond1: &Value{Type: types.String}},
+					},
+					Validators: []string{"uuid", "required", "hostname"},
+				},
+			},
+		},
+		{
+			name: "parse format rule on non-string field",
+			info: []StructInfo{
+				{
+					Name: "Server",
+					FieldList: []FieldInfo{
+						{Name: "Port", Tag: "port", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse email options",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "email=strict,deny_domains:a.com|b.com", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "email", Type: ruleValueConstraint, Field1: "Email", Cond1: &Value{Value: "strict,deny_domains:a.com|b.com", Type: types.String}},
+					},
+					Validators: []string{"email"},
+				},
+			},
+		},
+		{
+			name: "parse invalid email option",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "email=loose", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse substring rule without value",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "SKU", Tag: "starts_with", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse character class rule on non-string field",
+			info: []StructInfo{
+				{
+					Name: "Product",
+					FieldList: []FieldInfo{
+						{Name: "Stock", Tag: "numeric", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse string length rule without length",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Name", Tag: "max_graphemes=many", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse sanitizers",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Name", Tag: "trim;collapse_spaces;required", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required", Type: rulePresence, Field1: "Name", Cond1: &Value{Value: "", Type: types.String}},
+					},
+					Validators: []string{"required"},
+					Sanitizers: map[string][]string{"Name": {"trim", "collapse_spaces"}},
+				},
+			},
+		},
+		{
+			name: "parse sanitizer on non-string field",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Age", Tag: "trim", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Admin", Tag: "default=root@example.com;max=20", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "max", Type: ruleValueConstraint, Field1: "Admin", Cond1: &Value{Value: "20", Type: types.String}},
+					},
+					Validators: []string{"max"},
+					Defaults:   map[string]*Value{"Admin": {Value: "root@example.com", Type: types.String}},
+				},
+			},
+		},
+		{
+			name: "parse default with rules checked at runtime",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Name", Tag: "default=x;max_graphemes=5", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "max_graphemes", Type: ruleValueConstraint, Field1: "Name", Cond1: &Value{Value: "5", Type: types.String}},
+					},
+					Validators: []string{"max_graphemes"},
+					Defaults:   map[string]*Value{"Name": {Value: "x", Type: types.String}},
+				},
+			},
+		},
+		{
+			name: "parse default of invalid type",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Port", Tag: "default=http", Type: types.Uint16},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default failing its rules",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Port", Tag: "default=80;between=1024,65535", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse infinite float default",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Rate", Tag: "default=+Inf", Type: types.Float64},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse NaN float default",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Ratio", Tag: "default=NaN", Type: types.Float32},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse decimal default with leading zero",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Mode", Tag: "default=010", Type: types.Int},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules:    []SchemaRule{},
+					Defaults: map[string]*Value{"Mode": {Value: int64(10), Type: types.Int}},
+				},
+			},
+		},
+		{
+			name: "parse default failing its format rule",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Admin", Tag: "default=abc;email", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default failing its identifier rule",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Currency", Tag: "default=usd;iso4217", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default failing its sign rule",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Workers", Tag: "default=-5;positive", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default failing its numeric rules",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Workers", Tag: "default=5;gt=10;multiple_of=3", Type: types.Int},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse default failing its decimal places",
+			info: []StructInfo{
+				{
+					Name: "Config",
+					FieldList: []FieldInfo{
+						{Name: "Rate", Tag: "default=0.125;decimal_places=2", Type: types.Float64},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse invalid uuid version",
+			info: []StructInfo{
+				{
+					Name: "Server",
+					FieldList: []FieldInfo{
+						{Name: "ID", Tag: "uuid=9", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse identifier rules",
+			info: []StructInfo{
+				{
+					Name: "Payment",
+					FieldList: []FieldInfo{
+						{Name: "Account", Tag: "required;iban", Type: types.String},
+						{Name: "Currency", Tag: "iso4217", Type: types.String},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required", Type: rulePresence, Field1: "Account", Cond1: &Value{Value: "", Type: types.String}},
+						{Name: "iban", Type: ruleValueConstraint, Field1: "Account", Cond1: &Value{Type: types.String}},
+						{Name: "iso4217", Type: ruleValueConstraint, Field1: "Currency", Cond1: &Value{Type: types.String}},
+					},
+					Validators: []string{"required", "iban", "iso4217"},
+				},
+			},
+		},
+		{
+			name: "parse identifier rule with value",
+			info: []StructInfo{
+				{
+					Name: "Payment",
+					FieldList: []FieldInfo{
+						{Name: "Card", Tag: "luhn=16", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse unknown rule with value",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Name", Tag: "foo=3", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse misspelled presence rule",
+			info: []StructInfo{
+				{
+					Name: "User",
+					FieldList: []FieldInfo{
+						{Name: "Name", Tag: "requird", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse presence rule on non-basic field",
+			info: []StructInfo{
+				{
+					Name: "Post",
+					FieldList: []FieldInfo{
+						{Name: "Tags", Tag: "required", GoType: types.NewSlice(types.Typ[types.String])},
+					},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "required", Type: rulePresence, Field1: "Tags", Cond1: &Value{Type: types.Invalid}},
+					},
+					Validators: []string{"required"},
+				},
+			},
+		},
+		{
+			name: "parse value constraint rule on non-basic field",
+			info: []StructInfo{
+				{
+					Name: "Post",
+					FieldList: []FieldInfo{
+						{Name: "Tags", Tag: "min=2", GoType: types.NewSlice(types.Typ[types.String])},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse conditional rule without value",
+			info: []StructInfo{
+				{
+					Name: "Address",
+					FieldList: []FieldInfo{
+						{Name: "Zip", Tag: "exclude_if:Kind", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse conditional rule referencing missing field",
+			info: []StructInfo{
+				{
+					Name: "Address",
+					FieldList: []FieldInfo{
+						{Name: "Zip", Tag: "required_with:Nope", Type: types.String},
+					},
+					Pkg: newTestStruct("Address", map[string]types.Type{"Zip": types.Typ[types.String]}).Pkg,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse required unless rule without value",
+			info: []StructInfo{
+				{
+					Name: "Address",
+					FieldList: []FieldInfo{
+						{Name: "Phone", Tag: "required_unless:Country", Type: types.String},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "parse group rules",
+			info: []StructInfo{
+				{
+					Name: "Contact",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "email", Type: types.String},
+						{Name: "Phone", Tag: "min=6", Type: types.String},
+					},
+					GroupRules: []string{"one_of_required=Email,Phone@create"},
+				},
+			},
+			want: []Schema{
+				{
+					Rules: []SchemaRule{
+						{Name: "email", Type: ruleValueConstraint, Field1: "Email", Cond1: &Value{Type: types.String}},
+						{Name: "min", Type: ruleValueConstraint, Field1: "Phone", Cond1: &Value{Value: "6", Type: types.String}},
+						{Name: "one_of_required", Type: ruleGroup, Deps: []string{"Email", "Phone"}, Groups: []string{"create"}},
+					},
+					Validators: []string{"email", "min", "one_of_required"},
+				},
+			},
+		},
+		{
+			name: "parse group rule with unknown field",
+			info: []StructInfo{
+				{
+					Name: "Contact",
+					FieldList: []FieldInfo{
+						{Name: "Email", Tag: "email", Type: types.String},
+					},
+					GroupRules: []string{"mutually_exclusive=Email,Fax"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas, err := parseSchema(tt.info, tt.funcs)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				for i, want := range tt.want {
+					got := schemas[i]
+					assert.Equal(t, want.Rules, got.Rules)
+					for field, modifiers := range want.Modifiers {
+						assert.Equal(t, modifiers, got.Modifiers[field])
+					}
+					for field, sanitizers := range want.Sanitizers {
+						assert.Equal(t, sanitizers, got.Sanitizers[field])
+					}
+					for field, def := range want.Defaults {
+						assert.Equal(t, def, got.Defaults[field])
+					}
+					for _, v := range want.Validators {
+						assert.Contains(t, got.Validators, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test__parseExprRule(t *testing.T) {
+	t.Parallel()
+	stct := newTestStruct("Period", map[string]types.Type{
+		"Start": types.Typ[types.Int64],
+		"End":   types.Typ[types.Int64],
+		"Name":  types.Typ[types.String],
+	})
+
+	tests := [...]struct {
+		name    string
+		expr    string
+		want    SchemaRule
+		wantErr bool
+	}{
+		{
+			name: "parse cross-field expression",
+			expr: "End > Start && len(Name) < 10",
+			want: SchemaRule{
+				Name:   "expr",
+				Type:   ruleExpr,
+				Field1: "End",
+				Cond1:  &Value{Value: "End > Start && len(Name) < 10", Type: types.String},
+				Expr:   "u.End > u.Start && len(u.Name) < 10",
+				Deps:   []string{"Start", "Name"},
+			},
+		},
+		{name: "missing field", expr: "End > Begin", wantErr: true},
+		{name: "mistyped operands", expr: "End > Name", wantErr: true},
+		{name: "non boolean expression", expr: "End - Start", wantErr: true},
+		{name: "invalid expression", expr: "End >", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseExprRule(stct, FieldInfo{Name: "End", Type: types.Int64}, tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, rule)
+			}
+		})
+	}
+}
+
+func Test__parseCompareRule(t *testing.T) {
+	t.Parallel()
+	stct := newTestStruct("Product", map[string]types.Type{
+		"MinPrice": types.Typ[types.Float64],
+		"MaxPrice": types.Typ[types.Float64],
+		"MinQty":   types.Typ[types.Int8],
+		"MaxQty":   types.Typ[types.Int],
+		"Stock":    types.Typ[types.Uint],
+		"Name":     types.Typ[types.String],
+	})
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "same type", field: FieldInfo{Name: "MaxPrice", Type: types.Float64}, rule: "gte_field:MinPrice", want: "u.MaxPrice >= u.MinPrice"},
+		{name: "widen integers", field: FieldInfo{Name: "MaxQty", Type: types.Int}, rule: "gt_field:MinQty", want: "int64(u.MaxQty) > int64(u.MinQty)"},
+		{name: "signed with unsigned", field: FieldInfo{Name: "MaxQty", Type: types.Int}, rule: "lte_field:Stock", want: "(u.MaxQty < 0 || uint64(u.MaxQty) <= uint64(u.Stock))"},
+		{name: "signed greater than unsigned", field: FieldInfo{Name: "MaxQty", Type: types.Int}, rule: "gt_field:Stock", want: "u.MaxQty >= 0 && uint64(u.MaxQty) > uint64(u.Stock)"},
+		{name: "unsigned with signed", field: FieldInfo{Name: "Stock", Type: types.Uint}, rule: "gte_field:MinQty", want: "(u.MinQty < 0 || uint64(u.Stock) >= uint64(u.MinQty))"},
+		{name: "unsigned less than signed", field: FieldInfo{Name: "Stock", Type: types.Uint}, rule: "lt_field:MaxQty", want: "u.MaxQty >= 0 && uint64(u.Stock) < uint64(u.MaxQty)"},
+		{name: "widen float", field: FieldInfo{Name: "Stock", Type: types.Uint}, rule: "lte_field:MaxPrice", want: "float64(u.Stock) <= float64(u.MaxPrice)"},
+		{name: "missing field", field: FieldInfo{Name: "MaxPrice", Type: types.Float64}, rule: "gte_field:Price", wantErr: true},
+		{name: "incomparable fields", field: FieldInfo{Name: "MaxPrice", Type: types.Float64}, rule: "lt_field:Name", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(stct, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleCompare, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parseInRule(t *testing.T) {
+	t.Parallel()
+	pkg := types.NewPackage("example.com/user", "user")
+	status := types.NewNamed(types.NewTypeName(0, pkg, "Status", nil), types.Typ[types.String], nil)
+	pkg.Scope().Insert(status.Obj())
+	pkg.Scope().Insert(types.NewConst(1, pkg, "StatusActive", status, constant.MakeString("active")))
+	pkg.Scope().Insert(types.NewConst(2, pkg, "StatusBanned", status, constant.MakeString("banned")))
+
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "in strings", field: FieldInfo{Name: "Color", Type: types.String}, rule: "in=red,blue", want: `slices.Contains([]string{"red", "blue"}, string(u.Color))`},
+		{name: "not in integers", field: FieldInfo{Name: "Retries", Type: types.Int8}, rule: "not_in=0,13", want: "!slices.Contains([]int8{0, 13}, int8(u.Retries))"},
+		{name: "overflowing value", field: FieldInfo{Name: "Retries", Type: types.Int8}, rule: "in=1,300", wantErr: true},
+		{name: "invalid value", field: FieldInfo{Name: "Retries", Type: types.Int8}, rule: "in=one", wantErr: true},
+		{name: "enum constants", field: FieldInfo{Name: "Status", GoType: status}, rule: "enum", want: `slices.Contains([]string{"active", "banned"}, string(u.Status))`},
+		{name: "enum of unnamed type", field: FieldInfo{Name: "Color", Type: types.String}, rule: "enum", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleIn, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parseNumericRule(t *testing.T) {
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "gt fraction", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "gt=2.5", want: "u.Qty >= 3"},
+		{name: "lt fraction", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "lt=2.5", want: "u.Qty <= 2"},
+		{name: "lt negative", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "lt=-1", want: "u.Qty <= -2"},
+		{name: "gte float", field: FieldInfo{Name: "Rate", Type: types.Float32}, rule: "gte=1e-3", want: "float64(u.Rate) >= 0.001"},
+		{name: "lte beyond type", field: FieldInfo{Name: "Level", Type: types.Uint8}, rule: "lte=300", want: "true"},
+		{name: "gt beyond type", field: FieldInfo{Name: "Level", Type: types.Uint8}, rule: "gt=255", wantErr: true},
+		{name: "negative unsigned", field: FieldInfo{Name: "Level", Type: types.Uint8}, rule: "negative", wantErr: true},
+		{name: "positive", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "positive", want: "u.Qty >= 1"},
+		{name: "non zero", field: FieldInfo{Name: "Rate", Type: types.Float64}, rule: "non_zero", want: "float64(u.Rate) != 0.0"},
+		{name: "multiple of", field: FieldInfo{Name: "Step", Type: types.Int16}, rule: "multiple_of=5", want: "u.Step%5 == 0"},
+		{name: "multiple of beyond type", field: FieldInfo{Name: "Step", Type: types.Int8}, rule: "multiple_of=1000", want: "u.Step == 0"},
+		{name: "unsigned multiple of negative", field: FieldInfo{Name: "Step", Type: types.Uint}, rule: "multiple_of=-5", want: "u.Step%5 == 0"},
+		{name: "unsigned multiple of negative beyond type", field: FieldInfo{Name: "Step", Type: types.Uint8}, rule: "multiple_of=-300", want: "u.Step == 0"},
+		{name: "multiple of fraction", field: FieldInfo{Name: "Step", Type: types.Int}, rule: "multiple_of=0.5", wantErr: true},
+		{name: "digits", field: FieldInfo{Name: "PIN", Type: types.Uint16}, rule: "digits=4", want: "len(strconv.FormatUint(uint64(u.PIN), 10)) == 4"},
+		{name: "digits of float", field: FieldInfo{Name: "Rate", Type: types.Float64}, rule: "digits=4", wantErr: true},
+		{name: "decimal places", field: FieldInfo{Name: "Rate", Type: types.Float32}, rule: "decimal_places=2", want: "_Gov_DecimalPlaces(strconv.FormatFloat(float64(u.Rate), 'f', -1, 32)) <= 2"},
+		{name: "decimal places of int", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "decimal_places=2", wantErr: true},
+		{name: "string field", field: FieldInfo{Name: "Name", Type: types.String}, rule: "gt=1", wantErr: true},
+		{name: "invalid number", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "gt=one", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleNumeric, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parseBoundRule(t *testing.T) {
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    []*Value
+		wantErr bool
+	}{
+		{name: "max beyond type", field: FieldInfo{Name: "Level", Type: types.Uint8}, rule: "max=300", want: []*Value{{Type: types.Uint8, Value: uint64(255)}}},
+		{name: "min below type", field: FieldInfo{Name: "Level", Type: types.Uint}, rule: "min=-5", want: []*Value{{Type: types.Uint, Value: uint64(0)}}},
+		{name: "min fraction", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "min=2.5", want: []*Value{{Type: types.Int, Value: int64(3)}}},
+		{name: "between fractions", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "between=0.5,9.5", want: []*Value{{Type: types.Int, Value: int64(1)}, {Type: types.Int, Value: int64(9)}}},
+		{name: "min fraction of float", field: FieldInfo{Name: "Ratio", Type: types.Float64}, rule: "min=1/3", want: []*Value{{Type: types.Float64, Value: 1.0 / 3}}},
+		{name: "max hex of float", field: FieldInfo{Name: "Ratio", Type: types.Float64}, rule: "max=0x10", want: []*Value{{Type: types.Float64, Value: 16.0}}},
+		{name: "min beyond type", field: FieldInfo{Name: "Level", Type: types.Int8}, rule: "min=200", wantErr: true},
+		{name: "between without integer", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "between=1.2,1.8", wantErr: true},
+		{name: "between without max", field: FieldInfo{Name: "Qty", Type: types.Int}, rule: "between=1", wantErr: true},
+		{name: "min of uintptr", field: FieldInfo{Name: "Addr", Type: types.Uintptr}, rule: "min=1", wantErr: true},
+		{name: "between of uintptr", field: FieldInfo{Name: "Addr", Type: types.Uintptr}, rule: "between=1,2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want[0], rule.Cond1)
+				if len(tt.want) > 1 {
+					assert.Equal(t, tt.want[1], rule.Cond2)
+				}
+			}
+		})
+	}
+}
+
+func Test__parseBigNumberRule(t *testing.T) {
+	t.Parallel()
+	pkg := types.NewPackage("example.com/billing", "billing")
+	money := types.NewNamed(types.NewTypeName(0, pkg, "Money", nil), types.NewStruct(nil, nil), nil)
+	recv := types.NewParam(0, pkg, "m", money)
+	money.AddMethod(types.NewFunc(0, pkg, "Cmp", types.NewSignatureType(recv, nil, nil,
+		types.NewTuple(types.NewParam(0, pkg, "d", money)), types.NewTuple(types.NewParam(0, pkg, "", types.Typ[types.Int])), false)))
+	money.AddMethod(types.NewFunc(0, pkg, "String", types.NewSignatureType(recv, nil, nil,
+		nil, types.NewTuple(types.NewParam(0, pkg, "", types.Typ[types.String])), false)))
+	point := types.NewNamed(types.NewTypeName(0, pkg, "Point", nil), types.NewStruct(nil, nil), nil)
+
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "min", field: FieldInfo{Name: "Total", GoType: money}, rule: "min=10.50", want: `govader.Compare(u.Total, ">=", "10.50")`},
+		{name: "positive", field: FieldInfo{Name: "Total", GoType: money}, rule: "positive", want: `govader.Compare(u.Total, ">", "0")`},
+		{name: "between fractions", field: FieldInfo{Name: "Total", GoType: money}, rule: "between=1/3,2/3", want: `govader.Compare(u.Total, ">=", "1/3") && govader.Compare(u.Total, "<=", "2/3")`},
+		{name: "decimal places", field: FieldInfo{Name: "Total", GoType: money}, rule: "decimal_places=2", want: "govader.DecimalPlaces(u.Total, 2)"},
+		{name: "empty between", field: FieldInfo{Name: "Total", GoType: money}, rule: "between=2,1", wantErr: true},
+		{name: "invalid number", field: FieldInfo{Name: "Total", GoType: money}, rule: "max=1e", wantErr: true},
+		{name: "unsupported rule", field: FieldInfo{Name: "Total", GoType: money}, rule: "multiple_of=5", wantErr: true},
+		{name: "not a number", field: FieldInfo{Name: "Origin", GoType: point}, rule: "gt=0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleNumeric, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parseNetRule(t *testing.T) {
+	t.Parallel()
+	named := func(path, name string) types.Type {
+		pkg := types.NewPackage(path, path[strings.LastIndex(path, "/")+1:])
+		return types.NewNamed(types.NewTypeName(0, pkg, name, nil), types.NewStruct(nil, nil), nil)
+	}
+	addr, prefix := named("net/netip", "Addr"), named("net/netip", "Prefix")
+	ip, link := named("net", "IP"), types.NewPointer(named("net/url", "URL"))
+
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "addr ipv4", field: FieldInfo{Name: "Host", GoType: addr}, rule: "ipv4", want: "!u.Host.IsValid() || u.Host.Unmap().Is4()"},
+		{name: "addr in prefix", field: FieldInfo{Name: "Host", GoType: addr}, rule: "in_prefix=10.0.0.0/8,fd00::/8", want: `!u.Host.IsValid() || govader.InPrefix(u.Host.Unmap(), u.Host.Unmap().BitLen(), "10.0.0.0/8", "fd00::/8")`},
+		{name: "prefix in prefix", field: FieldInfo{Name: "Subnet", GoType: prefix}, rule: "in_prefix=10.0.0.0/8", want: `!u.Subnet.IsValid() || govader.InPrefix(u.Subnet.Addr(), u.Subnet.Bits(), "10.0.0.0/8")`},
+		{name: "ip private", field: FieldInfo{Name: "Gateway", GoType: ip}, rule: "private", want: "len(u.Gateway) == 0 || govader.IPAddr(u.Gateway).IsPrivate()"},
+		{name: "url scheme", field: FieldInfo{Name: "Link", GoType: link}, rule: "scheme=HTTPS,http", want: `u.Link == nil || slices.Contains([]string{"https", "http"}, u.Link.Scheme)`},
+		{name: "url without userinfo", field: FieldInfo{Name: "Link", GoType: link}, rule: "no_userinfo", want: "u.Link == nil || u.Link.User == nil"},
+		{name: "invalid prefix", field: FieldInfo{Name: "Host", GoType: addr}, rule: "in_prefix=10.0.0.0", wantErr: true},
+		{name: "missing value", field: FieldInfo{Name: "Link", GoType: link}, rule: "host_in", wantErr: true},
+		{name: "url rule on addr", field: FieldInfo{Name: "Host", GoType: addr}, rule: "scheme=https", wantErr: true},
+		{name: "addr rule on url", field: FieldInfo{Name: "Link", GoType: link}, rule: "private", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleNet, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parseCollectionRule(t *testing.T) {
+	t.Parallel()
+	pkg := types.NewPackage("example.com/order", "order")
+	item := types.NewNamed(types.NewTypeName(0, pkg, "Item", nil), types.NewStruct([]*types.Var{
+		types.NewField(0, pkg, "SKU", types.Typ[types.String], false),
+		types.NewField(0, pkg, "Qty", types.Typ[types.Int32], false),
+		types.NewField(0, pkg, "Attrs", types.NewMap(types.Typ[types.String], types.Typ[types.String]), false),
+	}, nil), nil)
+	items, lines := types.NewSlice(item), types.NewSlice(types.NewPointer(item))
+	tags := types.NewSlice(types.Typ[types.String])
+
+	tests := [...]struct {
+		name    string
+		field   FieldInfo
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "distinct", field: FieldInfo{Name: "Tags", GoType: tags}, rule: "distinct", want: "_Gov_Duplicate(len(u.Tags), func(i int) any { return u.Tags[i] })"},
+		{name: "unique by key", field: FieldInfo{Name: "Items", GoType: items}, rule: "unique=SKU", want: "_Gov_Duplicate(len(u.Items), func(i int) any { return any(u.Items[i].SKU) })"},
+		{name: "unique by key of pointers", field: FieldInfo{Name: "Lines", GoType: lines}, rule: "unique=SKU", want: "_Gov_Duplicate(len(u.Lines), func(i int) any { if u.Lines[i] == nil { return nil }; return any(u.Lines[i].SKU) })"},
+		{name: "contains", field: FieldInfo{Name: "Tags", GoType: tags}, rule: "contains=admin", want: `slices.Contains(u.Tags, "admin")`},
+		{name: "sum", field: FieldInfo{Name: "Items", GoType: items}, rule: "sum(Qty)=100", want: "_Gov_Sum(len(u.Items), func(i int) int64 { return int64(u.Items[i].Qty) }) == 100"},
+		{name: "sum max", field: FieldInfo{Name: "Items", GoType: items}, rule: "sum(Qty)<=10", want: "_Gov_Sum(len(u.Items), func(i int) int64 { return int64(u.Items[i].Qty) }) <= 10"},
+		{name: "sum of fraction", field: FieldInfo{Name: "Items", GoType: items}, rule: "sum(Qty)=1.5", wantErr: true},
+		{name: "sum of strings", field: FieldInfo{Name: "Items", GoType: items}, rule: "sum(SKU)=1", wantErr: true},
+		{name: "unknown key", field: FieldInfo{Name: "Items", GoType: items}, rule: "unique=ID", wantErr: true},
+		{name: "incomparable key", field: FieldInfo{Name: "Items", GoType: items}, rule: "unique=Attrs", wantErr: true},
+		{name: "incomparable elements", field: FieldInfo{Name: "Items", GoType: types.NewSlice(tags)}, rule: "distinct", wantErr: true},
+		{name: "contains struct", field: FieldInfo{Name: "Items", GoType: items}, rule: "contains=A", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(StructInfo{Pkg: pkg}, tt.field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ruleCollection, rule.Type)
+				assert.Equal(t, tt.want, rule.Expr)
+			}
+		})
+	}
+}
+
+func Test__parsePasswordRule(t *testing.T) {
+	t.Parallel()
+	stct := newTestStruct("Signup", map[string]types.Type{
+		"Email":    types.Typ[types.String],
+		"Age":      types.Typ[types.Int],
+		"Password": types.Typ[types.String],
+	})
+	field := FieldInfo{Name: "Password", Type: types.String}
+
+	tests := [...]struct {
+		name    string
+		rule    string
+		want    passwordPolicy
+		deps    []string
+		wantErr bool
+	}{
+		{name: "default policy", rule: "password", want: passwordPolicy{Min: 8}},
+		{
+			name: "all options",
+			rule: "password=min:12,classes:upper|symbol,max_repeat:3,not:Email,breached",
+			want: passwordPolicy{Min: 12, Classes: []string{"upper", "symbol"}, MaxRepeat: 3, Not: []string{"Email"}, Breached: true},
+			deps: []string{"Email"},
+		},
+		{name: "unknown option", rule: "password=max:64", wantErr: true},
+		{name: "unknown class", rule: "password=classes:emoji", wantErr: true},
+		{name: "invalid count", rule: "password=min:many", wantErr: true},
+		{name: "breached with value", rule: "password=breached:yes", wantErr: true},
+		{name: "missing field", rule: "password=not:Name", wantErr: true},
+		{name: "non string field", rule: "password=not:Age", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRule(stct, field, tt.rule, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, rulePassword, rule.Type)
+				assert.Equal(t, tt.deps, rule.Deps)
+				policy, err := parsePasswordPolicy(rule.Cond1.Value.(string))
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, policy)
+			}
+		})
+	}
+}
+
+func Test__parseRuleGroups(t *testing.T) {
+	t.Parallel()
+	tests := [...]struct {
+		rule   string
+		want   string
+		groups []string
+	}{
+		{rule: "required", want: "required"},
+		{rule: "required@create,update", want: "required", groups: []string{"create", "update"}},
+		{rule: "regexp=^.+@.+$", want: "regexp=^.+@.+$"},
+		{rule: `ends_with=\@acme`, want: "ends_with=@acme"},
+		{rule: `in=a,\@b`, want: "in=a,@b"},
+		{rule: `ends_with=\@acme@create`, want: "ends_with=@acme", groups: []string{"create"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, groups := parseRuleGroups(tt.rule)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.groups, groups)
+		})
+	}
+}
+
+func Test__isBuiltinRule(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"required", "min", "email", "gt_field", "password_min", "trim", "sometimes", "in"} {
+		assert.True(t, isBuiltinRule(name), name)
+	}
+	for _, name := range []string{"sku", "even", "color"} {
+		assert.False(t, isBuiltinRule(name), name)
+	}
+}
+
+// newTestStruct declares a struct type with the given fields in a new package.
+func newTestStruct(name string, fieldTypes map[string]types.Type) StructInfo {
+	pkg := types.NewPackage("example.com/"+strings.ToLower(name), strings.ToLower(name))
+	names := slices.Sorted(maps.Keys(fieldTypes))
+	fields := make([]*types.Var, 0, len(names))
+	for _, field := range names {
+		fields = append(fields, types.NewField(0, pkg, field, fieldTypes[field], false))
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			schemas, err := parseSchema(tt.info)
-			if tt.wantErr {
-				assert.NoError(t, err)
-			} else {
-				assert.NoError(t, err)
-				for i, want := range tt.want {
-					got := schemas[i]
-					assert.Equal(t, want.Rules, got.Rules)
-					for _, v := range want.Validators {
-						assert.Contains(t, got.Validators, v)
-					}
-				}
-			}
-		})
-	}
+	obj := types.NewTypeName(0, pkg, name, nil)
+	types.NewNamed(obj, types.NewStruct(fields, nil), nil)
+	pkg.Scope().Insert(obj)
+	return StructInfo{Name: name, FieldNames: names, Pkg: pkg}
 }
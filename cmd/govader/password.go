@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// passwordOptions contains list of options of the password rule, lists are
+// separated by '|', e.g. password=min:12,classes:upper|digit,not:Email,breached.
+var passwordOptions = []string{"min", "classes", "max_repeat", "not", "breached"}
+
+// passwordClasses contains list of character classes a password may require.
+var passwordClasses = []string{"upper", "lower", "digit", "symbol"}
+
+// passwordPolicy is the parsed value of a password rule.
+type passwordPolicy struct {
+	Min       int      // Minimum length in runes, 8 by default.
+	Classes   []string // Required character classes.
+	MaxRepeat int      // Maximum run of a repeated character, unlimited if zero.
+	Not       []string // Fields the password must not contain.
+	Breached  bool     // Check the password with the injected BreachChecker.
+}
+
+// parsePasswordPolicy parses the options of a password rule.
+func parsePasswordPolicy(value string) (passwordPolicy, error) {
+	policy := passwordPolicy{Min: 8}
+	if value == "" {
+		return policy, nil
+	}
+	for _, opt := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(opt, ":")
+		if !slices.Contains(passwordOptions, name) {
+			return policy, fmt.Errorf("invalid password option %s, expected one of %s", opt, strings.Join(passwordOptions, ", "))
+		}
+		if (name == "breached") != (arg == "") {
+			return policy, fmt.Errorf("invalid password option %s", opt)
+		}
+		var err error
+		switch name {
+		case "min":
+			policy.Min, err = strconv.Atoi(arg)
+		case "max_repeat":
+			policy.MaxRepeat, err = strconv.Atoi(arg)
+		case "classes":
+			policy.Classes = strings.Split(arg, "|")
+			for _, class := range policy.Classes {
+				if !slices.Contains(passwordClasses, class) {
+					return policy, fmt.Errorf("invalid password class %s, expected one of %s", class, strings.Join(passwordClasses, ", "))
+				}
+			}
+		case "not":
+			policy.Not = strings.Split(arg, "|")
+		case "breached":
+			policy.Breached = true
+		}
+		if err != nil || policy.Min < 0 || policy.MaxRepeat < 0 {
+			return policy, fmt.Errorf("invalid password option %s, expected a count", opt)
+		}
+	}
+	return policy, nil
+}
+
+// parsePasswordRule parses a password rule and checks the fields the
+// password must not contain are strings of the struct.
+func parsePasswordRule(stct StructInfo, f FieldInfo, ruleValue string) (SchemaRule, error) {
+	if f.Type != types.String {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: rule password needs a string field, got %s", f.Pos, f.Name, f.typ())
+	}
+	policy, err := parsePasswordPolicy(ruleValue)
+	if err != nil {
+		return SchemaRule{}, fmt.Errorf("%s: field %s: %s", f.Pos, f.Name, err)
+	}
+	for _, field := range policy.Not {
+		t := stct.fieldType(field)
+		if t == nil {
+			return SchemaRule{}, fmt.Errorf("%s: field %s: rule password references missing field %s", f.Pos, f.Name, field)
+		}
+		if basic, ok := t.Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+			return SchemaRule{}, fmt.Errorf("%s: field %s: rule password needs string field %s, got %s", f.Pos, f.Name, field, t)
+		}
+	}
+	return SchemaRule{
+		Name:   "password",
+		Type:   rulePassword,
+		Field1: f.Name,
+		Cond1:  &Value{Type: types.String, Value: ruleValue},
+		Deps:   policy.Not,
+	}, nil
+}
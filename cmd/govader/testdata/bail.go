@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Bail struct {
+	ID   int64  `gov:"bail;min=10;max=20;between=30,40;size=3"`
+	Age  int    `gov:"min=18;between=18,99"`
+	Name string `gov:"required"`
+}
+
+func main() {
+	// Stops at the first failing rule of ID, but not of Age.
+	b0 := Bail{ID: 5, Age: 1}
+	ck(NewBailSchema(b0).Validate(), []string{
+		"The ID field must be at least 10.",
+		"The Age field must be at least 18.",
+		"The Age field must be between 18 and 99.",
+		"The Name field is required.",
+	})
+
+	// Stops after the first N errors overall.
+	ck(NewBailSchema(b0).StopAfter(2).Validate(), []string{
+		"The ID field must be at least 10.",
+		"The Age field must be at least 18.",
+	})
+	ck(NewBailSchema(b0).StopAfter(1).Validate(), []string{
+		"The ID field must be at least 10.",
+	})
+
+	// Bails on the range rule once earlier rules of ID passed.
+	b1 := Bail{ID: 15, Age: 20, Name: "Jane"}
+	ck(NewBailSchema(b1).StopAfter(1).Validate(), []string{
+		"The ID field must be between 30 and 40.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"bail.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Cents is a decimal money type with two decimal places.
+type Cents struct{ n int64 }
+
+func (c Cents) Cmp(d Cents) int { return big.NewInt(c.n).Cmp(big.NewInt(d.n)) }
+func (c Cents) String() string  { return fmt.Sprintf("%d.%02d", c.n/100, c.n%100) }
+
+type Bignum struct {
+	Supply  *big.Int   `gov:"positive;max=1000000000000000000000001"`
+	Ratio   *big.Rat   `gov:"between=0,1/3"`
+	Rate    *big.Float `gov:"gt=0.1;decimal_places=2"`
+	Balance Cents      `gov:"min=10.50;max=99.99"`
+	Share   *big.Rat   `gov:"decimal_places=4"`
+	Missing *big.Int   `gov:"min=1"`
+}
+
+func main() {
+	b0 := Bignum{
+		Supply:  new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil),
+		Ratio:   big.NewRat(1, 3),
+		Rate:    big.NewFloat(0.25),
+		Balance: Cents{1050},
+		Share:   big.NewRat(1, 8),
+	}
+	ck(NewBignumSchema(b0).Validate(), []string(nil))
+
+	supply, _ := new(big.Int).SetString("1000000000000000000000002", 10)
+	b1 := Bignum{
+		Supply:  supply,
+		Ratio:   new(big.Rat).Add(big.NewRat(1, 3), big.NewRat(1, 1<<62)),
+		Rate:    big.NewFloat(0.0625),
+		Balance: Cents{1049},
+		Share:   big.NewRat(1, 3),
+	}
+	ck(NewBignumSchema(b1).Validate(), []string{
+		"The Supply field may not be greater than 1000000000000000000000001.",
+		"The Ratio field must be between 0 and 1/3.",
+		"The Rate field must be greater than 0.1.",
+		"The Rate field may not have more than 2 decimal places.",
+		"The Balance field must be at least 10.50.",
+		"The Share field may not have more than 4 decimal places.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"bignum.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
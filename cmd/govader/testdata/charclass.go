@@ -0,0 +1,71 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Charclass struct {
+	Name     string `gov:"alpha"`
+	Code     string `gov:"alpha_num"`
+	Slug     string `gov:"alpha_dash;lowercase"`
+	PIN      string `gov:"numeric"`
+	Key      string `gov:"ascii;uppercase"`
+	Title    string `gov:"printable"`
+	Username string `gov:"no_whitespace;excludes=admin"`
+	SKU      string `gov:"starts_with=SKU-;ends_with=-X"`
+	Bio      string `gov:"contains=go"`
+}
+
+func main() {
+	c0 := Charclass{
+		Name:     "Zoë",
+		Code:     "Ünïcode42",
+		Slug:     "hello-wörld_2",
+		PIN:      "0042",
+		Key:      "ABC_1",
+		Title:    "Hello, World!",
+		Username: "gopher",
+		SKU:      "SKU-123-X",
+		Bio:      "I like go",
+	}
+	ck(NewCharclassSchema(c0).Validate(), []string(nil))
+
+	c1 := Charclass{
+		Name:     "Zoë 2",
+		Code:     "a-b",
+		Slug:     "Hello world",
+		PIN:      "٤٢",
+		Key:      "Straße",
+		Title:    "bell\a",
+		Username: "super admin",
+		SKU:      "123",
+		Bio:      "rust",
+	}
+	ck(NewCharclassSchema(c1).Validate(), []string{
+		"The Name field must contain only letters.",
+		"The Code field must contain only letters and digits.",
+		"The Slug field must contain only letters, digits, dashes and underscores.",
+		"The Slug field must be lowercase.",
+		"The PIN field must contain only digits.",
+		"The Key field must contain only ASCII characters.",
+		"The Key field must be uppercase.",
+		"The Title field must contain only printable characters.",
+		"The Username field must not contain whitespace.",
+		"The Username field must not contain admin.",
+		"The SKU field must start with SKU-.",
+		"The SKU field must end with -X.",
+		"The Bio field must contain go.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"charclass.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
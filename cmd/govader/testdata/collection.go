@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Item struct {
+	SKU     string
+	Percent float64
+	Qty     int
+}
+
+type Collection struct {
+	Tags   []string `gov:"distinct"`
+	Roles  []string `gov:"contains=admin"`
+	Items  []Item   `gov:"unique=SKU;sum(Percent)=100;sum(Qty)<=10"`
+	Lines  []*Item  `gov:"unique=SKU;sum(Qty)>=1"`
+	Scores []uint8  `gov:"distinct;contains=100"`
+	Codes  []string `gov:"sometimes;distinct"`
+}
+
+func main() {
+	c0 := Collection{
+		Tags:  []string{"go", "rust"},
+		Roles: []string{"user", "admin"},
+		Items: []Item{
+			{SKU: "A", Percent: 33.3, Qty: 1},
+			{SKU: "B", Percent: 33.3, Qty: 2},
+			{SKU: "C", Percent: 33.4, Qty: 3},
+		},
+		Lines:  []*Item{nil, {SKU: "A", Qty: 1}, nil},
+		Scores: []uint8{100, 90},
+	}
+	ck(NewCollectionSchema(c0).Validate(), []string(nil))
+
+	c1 := Collection{
+		Tags:  []string{"go", "rust", "go"},
+		Roles: []string{"user"},
+		Items: []Item{
+			{SKU: "A", Percent: 50, Qty: 5},
+			{SKU: "B", Percent: 40, Qty: 5},
+			{SKU: "C", Qty: 1},
+			{SKU: "D"},
+			{SKU: "B"},
+		},
+		Lines:  []*Item{{SKU: "A"}, nil, {SKU: "A"}},
+		Scores: []uint8{90, 90},
+		Codes:  []string{"a", "b", "a"},
+	}
+	ck(NewCollectionSchema(c1).Validate(), []string{
+		"The Tags[2] field duplicates Tags[0].",
+		"The Roles field must contain admin.",
+		"The Items[4].SKU field duplicates Items[1].SKU.",
+		"The sum of Percent in Items must be 100.",
+		"The sum of Qty in Items may not be greater than 10.",
+		"The Lines[2].SKU field duplicates Lines[0].SKU.",
+		"The sum of Qty in Lines must be at least 1.",
+		"The Scores[1] field duplicates Scores[0].",
+		"The Scores field must contain 100.",
+		"The Codes[2] field duplicates Codes[0].",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"collection.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
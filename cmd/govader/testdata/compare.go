@@ -0,0 +1,72 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Price float64
+
+type Compare struct {
+	MinPrice Price
+	MaxPrice Price   `gov:"gte_field:MinPrice"`
+	Total    float64 `gov:"gt_field:MinPrice"`
+	Min      int8
+	Max      int64 `gov:"gt_field:Min"`
+	Low      uint16
+	High     uint `gov:"gte_field:Low"`
+	First    string
+	Last     string `gov:"gt_field:First"`
+	Start    time.Time
+	End      time.Time `gov:"gt_field:Start"`
+	Limit    int       `gov:"lt_field:Max;lte_field:Total"`
+	Signed   int64
+	Unsigned uint64 `gov:"gt_field:Signed"`
+}
+
+func main() {
+	now := time.Now()
+	c0 := Compare{
+		MinPrice: 10, MaxPrice: 10, Total: 11,
+		Min: -1, Max: 5,
+		Low: 2, High: 2,
+		First: "a", Last: "b",
+		Start: now, End: now.Add(time.Hour),
+		Limit:  4,
+		Signed: 1 << 53, Unsigned: 1<<53 + 1, // Equal as float64.
+	}
+	ck(NewCompareSchema(c0).Validate(), []string(nil))
+
+	c1 := Compare{
+		MinPrice: 10, MaxPrice: 9, Total: 10,
+		Min: 5, Max: 5,
+		Low: 3, High: 2,
+		First: "b", Last: "a",
+		Start: now, End: now,
+		Limit:  12,
+		Signed: 1<<53 + 1, Unsigned: 1<<53 + 1,
+	}
+	ck(NewCompareSchema(c1).Validate(), []string{
+		"The MaxPrice field must be greater than or equal to the MinPrice field.",
+		"The Total field must be greater than the MinPrice field.",
+		"The Max field must be greater than the Min field.",
+		"The High field must be greater than or equal to the Low field.",
+		"The Last field must be greater than the First field.",
+		"The End field must be greater than the Start field.",
+		"The Limit field must be less than the Max field.",
+		"The Limit field must be less than or equal to the Total field.",
+		"The Unsigned field must be greater than the Signed field.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"compare.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Conditional struct {
+	Kind    string
+	Country string
+	Street  string
+	City    string
+	Zip     string `gov:"exclude_if:Kind=guest;required_if:Country=US,CA;required_with_all:Street,City;size=5"`
+	Phone   string `gov:"required_unless:Country=US;required_without_all:Street,City"`
+	Coupon  string `gov:"prohibited_if:Kind=guest,trial"`
+	Note    string `gov:"prohibited_unless:Kind=admin;uppercase"`
+}
+
+func main() {
+	ck(NewConditionalSchema(Conditional{Country: "US", Street: "Main", City: "Springfield", Zip: "12345"}).Validate(), []string(nil))
+
+	// Guests are excluded from all Zip rules.
+	ck(NewConditionalSchema(Conditional{Kind: "guest", Country: "CA", Street: "Main", City: "Toronto"}).Validate(), []string{
+		"The Phone field is required unless Country is in US.",
+	})
+
+	ck(NewConditionalSchema(Conditional{Kind: "trial", Country: "CA", Coupon: "FREE", Note: "hi"}).Validate(), []string{
+		"The Zip field is required when Country is US, CA.",
+		"The Phone field is required unless Country is in US.",
+		"The Phone field is required when none of Street, City are present.",
+		"The Coupon field is prohibited when Kind is guest, trial.",
+		"The Note field is prohibited unless Kind is in admin.",
+		"The Note field must be uppercase.",
+	})
+
+	ck(NewConditionalSchema(Conditional{Country: "DE", Street: "Main", City: "Berlin", Phone: "1"}).Validate(), []string{
+		"The Zip field is required when Street, City are present.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"conditional.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Context struct {
+	Email string `gov:"required;unique=users.email"`
+	Name  string `gov:"required;unique=users.name"`
+}
+
+// memoryStore is an in-memory govader.UniqueChecker.
+type memoryStore map[string][]any
+
+func (m memoryStore) Unique(ctx context.Context, table, column string, value any) (bool, error) {
+	for _, v := range m[table+"."+column] {
+		if v == value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func main() {
+	svc := govader.Services{Unique: memoryStore{"users.email": {"jane@gmail.com"}}}
+	c0 := Context{Email: "jane@gmail.com", Name: "Jane"}
+
+	// Rules which need I/O are skipped without a context.
+	ck(NewContextSchema(c0).Validate(), []string(nil))
+
+	messages, err := NewContextSchema(c0).ValidateContext(context.Background(), svc)
+	if err != nil {
+		panic("context.go: " + err.Error())
+	}
+	ck(messages, []string{
+		"The Email has already been taken.",
+	})
+
+	// Unique rules are skipped once required failed.
+	messages, _ = NewContextSchema(Context{}).ValidateContext(context.Background(), svc)
+	ck(messages, []string{
+		"The Email field is required.",
+		"The Name field is required.",
+	})
+
+	// Cancellation stops validation and returns the context error.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewContextSchema(c0).ValidateContext(ctx, svc); !errors.Is(err, context.Canceled) {
+		panic("context.go: want context.Canceled")
+	}
+
+	// Dispatch by dynamic type through the registry.
+	validator := govader.Validator{Services: svc}
+	err = validator.ValidateContext(context.Background(), c0)
+	ck(err.(govader.Errors), []string{
+		"The Email has already been taken.",
+	})
+	if err := govader.ValidateContext(context.Background(), c0); err == nil {
+		panic("context.go: want error for missing UniqueChecker")
+	}
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"context.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+type SKU string
+
+type Custom struct {
+	Code  SKU    `gov:"required;sku=AB,6"`
+	Color string `gov:"color"`
+	Qty   int    `gov:"even"`
+}
+
+//govader:rule name=sku message="The :field field must be a valid \"SKU\" with prefix and length :value."
+func validateSKU(value SKU, prefix string, length int) error {
+	if !strings.HasPrefix(string(value), prefix) || len(value) != length {
+		return errors.New("invalid sku")
+	}
+	return nil
+}
+
+//govader:rule name=color
+func validateColor(value string) error {
+	switch value {
+	case "red", "green", "blue":
+		return nil
+	}
+	return errors.New("The Color field must be red, green or blue.")
+}
+
+//govader:rule name=even message="The :field field must be even." message.en="The :field field must be an even number." message.ar=":field يجب أن يكون زوجيا."
+func isEven(value int) error {
+	if value%2 != 0 {
+		return errors.New("odd")
+	}
+	return nil
+}
+
+func main() {
+	c0 := Custom{Code: "AB1234", Color: "red", Qty: 2}
+	ck(NewCustomSchema(c0).Validate(), []string(nil))
+
+	c1 := Custom{Code: "XY1234", Color: "pink", Qty: 3}
+	ck(NewCustomSchema(c1).Validate(), []string{
+		"The Code field must be a valid \"SKU\" with prefix and length AB,6.",
+		"The Color field must be red, green or blue.",
+		"The Qty field must be an even number.",
+	})
+
+	// Custom rules are value rules, skipped once required failed.
+	c2 := Custom{Color: "red"}
+	ck(NewCustomSchema(c2).Validate(), []string{
+		"The Code field is required.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"custom.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Mode string
+
+const (
+	ModeDev  Mode = "dev"
+	ModeProd Mode = "prod"
+)
+
+type Defaults struct {
+	Host    string  `gov:"default=localhost;hostname"`
+	Port    int     `gov:"default=8080;between=1,65535"`
+	Mode    Mode    `gov:"default=dev;enum"`
+	Ratio   float64 `gov:"default=0.5;max=1"`
+	Debug   bool    `gov:"default=true"`
+	Admin   string  `gov:"default=root@example.com;email"`
+	Name    string  `gov:"default=  svc ;trim;required"`
+	Retries uint8   `gov:"default=3"`
+	Workers int     `gov:"default=6;gt=1;lte=9;multiple_of=3;digits=1"`
+	Rate    float64 `gov:"default=0.3;multiple_of=0.1;decimal_places=1;non_zero"`
+	Label   string  `gov:"default=café;max_graphemes=5"`
+}
+
+func main() {
+	d0 := Defaults{Port: 443, Mode: ModeProd}
+	ck(ValidateAndNormalizeDefaults(&d0), []string(nil))
+	want := Defaults{Host: "localhost", Port: 443, Mode: ModeProd, Ratio: 0.5, Debug: true, Admin: "root@example.com", Name: "svc", Retries: 3, Workers: 6, Rate: 0.3, Label: "café"}
+	if d0 != want {
+		panic("defaults.go: unexpected defaults")
+	}
+
+	// Defaults only fill zero values, explicit values are still validated.
+	d1 := Defaults{Port: 70000, Ratio: 2, Label: "crème brûlée"}
+	ApplyDefaultsDefaults(&d1)
+	ck(NewDefaultsSchema(d1).Validate(), []string{
+		"The Port field must be between 1 and 65535.",
+		"The Ratio field may not be greater than 1.",
+		"The Label field may not be greater than 5 graphemes.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"defaults.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Email struct {
+	Contact string `gov:"email"`
+	Login   string `gov:"email=strict"`
+	Intl    string `gov:"sometimes;email=allow_idn"`
+	Work    string `gov:"sometimes;email=allow_domains:example.com|Example.org"`
+	Signup  string `gov:"sometimes;email=deny_domains:mailinator.com,mx"`
+}
+
+// stubMX is a govader.MXChecker knowing the domains with mail exchange records.
+type stubMX []string
+
+func (s stubMX) HasMX(ctx context.Context, domain string) (bool, error) {
+	for _, d := range s {
+		if d == domain {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func main() {
+	e0 := Email{
+		Contact: "Jane Doe <jane@example.com>",
+		Login:   `"jane doe"@example.com`,
+		Intl:    "jane@bücher.de",
+		Work:    "jane@example.org",
+		Signup:  "jane@gmail.com",
+	}
+	ck(NewEmailSchema(e0).Validate(), []string(nil))
+
+	e1 := Email{
+		Contact: "a@b..com",
+		Login:   "Jane <jane@example.com>",
+		Intl:    "jane@localhost",
+		Work:    "jane@gmail.com",
+		Signup:  "jane@mailinator.com",
+	}
+	ck(NewEmailSchema(e1).Validate(), []string{
+		"The Contact field must be a valid email address.",
+		"The Login field must be a valid email address.",
+		"The Intl field must be a valid email address.",
+		"The Work field must not use the email domain gmail.com.",
+		"The Signup field must not use the email domain mailinator.com.",
+	})
+
+	// International domains need allow_idn.
+	ck(NewEmailSchema(Email{Contact: "jane@bücher.de", Login: "jane@example.com"}).Validate(), []string{
+		"The Contact field must be a valid email address.",
+	})
+
+	// The domain of the address is checked with the MX checker service.
+	svc := govader.Services{MX: stubMX{"gmail.com"}}
+	e2 := Email{Contact: "jane@example.com", Login: "jane@example.com", Signup: "jane@Nowhere.test"}
+	messages, err := NewEmailSchema(e2).ValidateContext(context.Background(), svc)
+	if err != nil {
+		panic("email.go: " + err.Error())
+	}
+	ck(messages, []string{
+		"The Signup field must use an email domain accepting mail, nowhere.test does not.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"email.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
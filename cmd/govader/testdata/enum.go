@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusPending Status = "pending"
+	StatusBanned  Status = "banned"
+)
+
+type Level uint8
+
+const (
+	LevelLow Level = iota + 1
+	LevelHigh
+)
+
+type Enum struct {
+	Status  Status `gov:"enum"`
+	Level   Level  `gov:"enum"`
+	Color   string `gov:"in=red,green,blue"`
+	Retries int8   `gov:"not_in=0,13"`
+	Name    string `gov:"sometimes;not_in=admin,root"`
+}
+
+func main() {
+	ck(NewEnumSchema(Enum{Status: StatusBanned, Level: LevelHigh, Color: "red", Retries: 3}).Validate(), []string(nil))
+
+	ck(NewEnumSchema(Enum{Status: "deleted", Level: 7, Color: "pink", Name: "root"}).Validate(), []string{
+		"The Status field must be one of active, pending, banned.",
+		"The Level field must be one of 1, 2.",
+		"The Color field must be one of red, green, blue.",
+		"The Retries field must not be one of 0, 13.",
+		"The Name field must not be one of admin, root.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"enum.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Expr struct {
+	Start    int64   `gov:"required"`
+	End      int64   `gov:"expr=End > Start"`
+	Price    float64 `gov:"min=1"`
+	Discount float64 `gov:"expr=Discount <= Price*0.5"`
+	Items    []string
+	Count    int `gov:"expr=len(Items) == Count"`
+}
+
+func main() {
+	e0 := Expr{Start: 1, End: 2, Price: 10, Discount: 5, Items: []string{"a"}, Count: 1}
+	ck(NewExprSchema(e0).Validate(), []string(nil))
+
+	e1 := Expr{Start: 2, End: 1, Price: 10, Discount: 6, Count: 1}
+	ck(NewExprSchema(e1).Validate(), []string{
+		"The End field must satisfy End > Start.",
+		"The Discount field must satisfy Discount <= Price*0.5.",
+		"The Count field must satisfy len(Items) == Count.",
+	})
+
+	// Expression rules run when their dependencies were touched.
+	ck(NewExprSchema(e1).ValidateFields("Start"), []string{
+		"The End field must satisfy End > Start.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"expr.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Fields struct {
+	ID    int64  `gov:"required;min=1"`
+	Age   int    `gov:"between=18,99;required_with:Name"`
+	Name  string `gov:"required"`
+	Email string `gov:"email"`
+	Bio   string
+}
+
+func main() {
+	f0 := Fields{}
+
+	// Only rules of the listed fields run.
+	ck(NewFieldsSchema(f0).ValidateFields("ID"), []string{
+		"The ID field is required.",
+	})
+	ck(NewFieldsSchema(f0).ValidateFields("Bio"), []string(nil))
+	ck(NewFieldsSchema(f0).ValidateFields(), []string(nil))
+
+	// Cross-field rules run when their dependency was touched.
+	f1 := Fields{Name: "Jane"}
+	ck(NewFieldsSchema(f1).ValidateFields("Name"), []string{
+		"The Age field is required when Name is present.",
+	})
+	ck(NewFieldsSchema(f1).ValidateFields("Age"), []string{
+		"The Age field must be between 18 and 99.",
+		"The Age field is required when Name is present.",
+	})
+
+	// Unknown paths are reported.
+	ck(NewFieldsSchema(f1).ValidateFields("Email", "Phone"), []string{
+		"The Phone field does not exist.",
+		"The Email field must be a valid email address.",
+	})
+
+	// Dispatch by dynamic type through the registry.
+	err := govader.ValidateFields(&f1, "Name")
+	ck(err.(govader.Errors), []string{
+		"The Age field is required when Name is present.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"fields.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
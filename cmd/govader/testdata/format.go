@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Format struct {
+	Homepage string `gov:"url"`
+	Callback string `gov:"http_url"`
+	ID       string `gov:"uuid=4"`
+	TraceID  string `gov:"uuid"`
+	EventID  string `gov:"ulid"`
+	Addr     string `gov:"ip"`
+	Gateway  string `gov:"ipv4"`
+	Peer     string `gov:"ipv6"`
+	Subnet   string `gov:"cidr"`
+	Host     string `gov:"hostname"`
+	Domain   string `gov:"fqdn"`
+	NIC      string `gov:"mac"`
+	Port     string `gov:"port"`
+}
+
+func main() {
+	valid := Format{
+		Homepage: "mailto:jane@example.com",
+		Callback: "https://example.com/hook?x=1",
+		ID:       "123e4567-e89b-42d3-a456-426614174000",
+		TraceID:  "123E4567-E89B-12D3-A456-426614174000",
+		EventID:  "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		Addr:     "::1",
+		Gateway:  "192.168.0.1",
+		Peer:     "2001:db8::1",
+		Subnet:   "10.0.0.0/8",
+		Host:     "db-1",
+		Domain:   "api.example.com.",
+		NIC:      "00:1a:2b:3c:4d:5e",
+		Port:     "8080",
+	}
+	ck(NewFormatSchema(valid).Validate(), []string(nil))
+
+	invalid := Format{
+		Homepage: "example.com",
+		Callback: "ftp://example.com",
+		ID:       "123e4567-e89b-12d3-a456-426614174000",
+		TraceID:  "123e4567e89b12d3a456426614174000",
+		EventID:  "81ARZ3NDEKTSV4RRFFQ69G5FAV",
+		Addr:     "256.0.0.1",
+		Gateway:  "2001:db8::1",
+		Peer:     "192.168.0.1",
+		Subnet:   "10.0.0.0",
+		Host:     "-db",
+		Domain:   "localhost",
+		NIC:      "00:1a:2b",
+		Port:     "65536",
+	}
+	ck(NewFormatSchema(invalid).Validate(), []string{
+		"The Homepage field must be a valid URL.",
+		"The Callback field must be a valid HTTP URL.",
+		"The ID field must be a valid UUID v4.",
+		"The TraceID field must be a valid UUID.",
+		"The EventID field must be a valid ULID.",
+		"The Addr field must be a valid IP address.",
+		"The Gateway field must be a valid IPv4 address.",
+		"The Peer field must be a valid IPv6 address.",
+		"The Subnet field must be a valid CIDR notation.",
+		"The Host field must be a valid hostname.",
+		"The Domain field must be a fully qualified domain name.",
+		"The NIC field must be a valid MAC address.",
+		"The Port field must be a valid port number.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"format.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
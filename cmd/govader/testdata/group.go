@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Group has fields which are only valid in combination.
+//
+//govader:group one_of_required=Email,Phone
+type Group struct {
+	_           struct{} `gov:"exactly_one_of=CardToken,BankAccount;mutually_exclusive=Coupon,Discount,GiftCard"`
+	Email       string
+	Phone       string
+	CardToken   string
+	BankAccount string
+	Coupon      string
+	Discount    int
+	GiftCard    string
+	Name        string `gov:"required"`
+}
+
+func main() {
+	ck(NewGroupSchema(Group{Name: "a", Email: "a@b.c", CardToken: "tok", Discount: 5}).Validate(), []string(nil))
+
+	ck(NewGroupSchema(Group{Name: "a"}).Validate(), []string{
+		"The Email field is required when none of Phone are present.",
+		"The Phone field is required when none of Email are present.",
+		"The CardToken field must be present if and only if none of BankAccount are present.",
+		"The BankAccount field must be present if and only if none of CardToken are present.",
+	})
+
+	ck(NewGroupSchema(Group{Name: "a", Phone: "1", CardToken: "tok", BankAccount: "DE00", Coupon: "X", GiftCard: "G"}).Validate(), []string{
+		"The CardToken field must be present if and only if none of BankAccount are present.",
+		"The BankAccount field must be present if and only if none of CardToken are present.",
+		"The Coupon field cannot be present together with GiftCard.",
+		"The GiftCard field cannot be present together with Coupon.",
+	})
+
+	// Group rules run in partial validation when one of their fields is touched.
+	ck(NewGroupSchema(Group{}).ValidateFields("Coupon"), []string(nil))
+	ck(NewGroupSchema(Group{}).ValidateFields("Email"), []string{
+		"The Email field is required when none of Phone are present.",
+		"The Phone field is required when none of Email are present.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"group.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
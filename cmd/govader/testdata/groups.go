@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Groups struct {
+	ID   int64  `gov:"required@update;prohibited@create"`
+	Age  int    `gov:"min=18@create,update"`
+	Name string `gov:"required"`
+	Team string `gov:"ends_with=\\@acme@update"` // An escaped '@' is part of the value.
+}
+
+func main() {
+	// Rules without a group apply always, grouped rules only for their groups.
+	g0 := Groups{ID: 1}
+	ck(NewGroupsSchema(g0).Validate(), []string{
+		"The Name field is required.",
+	})
+	ck(NewGroupsSchema(g0).ValidateGroups("create"), []string{
+		"The ID field is prohibited.",
+		"The Age field must be at least 18.",
+		"The Name field is required.",
+	})
+
+	g1 := Groups{Age: 20, Name: "Jane", Team: "dev@acme"}
+	ck(NewGroupsSchema(g1).ValidateGroups("create"), []string(nil))
+	ck(NewGroupsSchema(g1).ValidateGroups("update"), []string{
+		"The ID field is required.",
+	})
+
+	// Dispatch by dynamic type through the registry.
+	err := govader.ValidateGroups(g1, "update")
+	ck(err.(govader.Errors), []string{
+		"The ID field is required.",
+	})
+	if err := govader.ValidateGroups(g1, "create"); err != nil {
+		panic("groups.go: " + err.Error())
+	}
+
+	g2 := Groups{ID: 1, Age: 20, Name: "Jane", Team: "dev@corp"}
+	ck(NewGroupsSchema(g2).ValidateGroups("update"), []string{
+		"The Team field must end with @acme.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"groups.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Hook struct {
+	Total int64 `gov:"required"`
+	Item1 int64 `gov:"min=0"`
+	Item2 int64 `gov:"min=0"`
+	Note  string
+}
+
+func (h Hook) ValidateStruct(r *govader.Reporter) {
+	if h.Item1+h.Item2 != h.Total {
+		r.Report("Total", "sum", fmt.Sprintf("The Total field must equal the sum of items %d.", h.Item1+h.Item2))
+	}
+	if h.Note == "" {
+		r.Report("Note", "required", "")
+	}
+}
+
+func main() {
+	h0 := Hook{Total: 3, Item1: 1, Item2: 2, Note: "ok"}
+	ck(NewHookSchema(h0).Validate(), []string(nil))
+
+	// Hook errors are collected after the tag rules.
+	h1 := Hook{Item1: 1, Item2: 2}
+	ck(NewHookSchema(h1).Validate(), []string{
+		"The Total field is required.",
+		"The Total field must equal the sum of items 3.",
+		"The Note field is required.",
+	})
+	ck(NewHookSchema(h1).StopAfter(2).Validate(), []string{
+		"The Total field is required.",
+		"The Total field must equal the sum of items 3.",
+	})
+
+	// Partial validation keeps the hook errors of the given fields.
+	ck(NewHookSchema(h1).ValidateFields("Note"), []string{
+		"The Note field is required.",
+	})
+	ck(NewHookSchema(h1).ValidateFields("Item1"), []string(nil))
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"hook.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Identifier struct {
+	Card     string `gov:"luhn"`
+	Account  string `gov:"iban"`
+	Book     string `gov:"isbn10"`
+	Edition  string `gov:"isbn13"`
+	Phone    string `gov:"e164"`
+	Country  string `gov:"iso3166_alpha2"`
+	Origin   string `gov:"iso3166_alpha3"`
+	Currency string `gov:"iso4217"`
+	Language string `gov:"bcp47"`
+}
+
+func main() {
+	i0 := Identifier{
+		Card:     "4111 1111 1111 1111",
+		Account:  "GB82 WEST 1234 5698 7654 32",
+		Book:     "0-306-40615-2",
+		Edition:  "978-0-306-40615-7",
+		Phone:    "+14155552671",
+		Country:  "PK",
+		Origin:   "SAU",
+		Currency: "EUR",
+		Language: "zh-Hant-TW",
+	}
+	ck(NewIdentifierSchema(i0).Validate(), []string(nil))
+
+	i1 := Identifier{
+		Card:     "4111 1111 1111 1112",
+		Account:  "GB82 WEST 1234 5698 7654 33",
+		Book:     "0-306-40615-3",
+		Edition:  "977-0-306-40615-8",
+		Phone:    "004415552671",
+		Country:  "UK",
+		Origin:   "XYZ",
+		Currency: "EURO",
+		Language: "en_US",
+	}
+	ck(NewIdentifierSchema(i1).Validate(), []string{
+		"The Card field must be a valid card number.",
+		"The Account field must be a valid IBAN.",
+		"The Book field must be a valid ISBN-10.",
+		"The Edition field must be a valid ISBN-13.",
+		"The Phone field must be a valid E.164 phone number.",
+		"The Country field must be a valid ISO 3166-1 alpha-2 country code.",
+		"The Origin field must be a valid ISO 3166-1 alpha-3 country code.",
+		"The Currency field must be a valid ISO 4217 currency code.",
+		"The Language field must be a valid BCP 47 language tag.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"identifier.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Length struct {
+	Name    string `gov:"min=3;max=5"`
+	Code    string `gov:"size=2"`
+	Title   string `gov:"between=2,4"`
+	Slug    string `gov:"max_bytes=4"`
+	Status  string `gov:"max_graphemes=3;min_graphemes=2"`
+	Summary string `gov:"sometimes;min_bytes=3"`
+	Korean  string `gov:"sometimes;min_graphemes=2;max_graphemes=2"`
+	Lines   string `gov:"sometimes;max_graphemes=3"`
+}
+
+func main() {
+	// Runes are counted by default, e.g. an Urdu name of 5 letters and 10 bytes.
+	l0 := Length{Name: "احمدی", Code: "ää", Title: "صبح", Slug: "ab-c", Status: "🇵🇰👍🏽é"}
+	ck(NewLengthSchema(l0).Validate(), []string(nil))
+
+	// Hangul jamo compose syllables and CRLF is a single grapheme.
+	l2 := Length{Name: "abc", Code: "ab", Title: "ab", Status: "ab", Korean: "\u1112\u1161\u11ab\u1100\u116e\u11a8", Lines: "a\r\nb"}
+	ck(NewLengthSchema(l2).Validate(), []string(nil))
+	l2.Korean, l2.Lines = "한국", "\r\n\r\n\r\n"
+	ck(NewLengthSchema(l2).Validate(), []string(nil))
+	l2.Korean, l2.Lines = "\uac00\u11a8", "a\r\n\r\nb"
+	ck(NewLengthSchema(l2).Validate(), []string{
+		"The Korean field must be at least 2 graphemes.",
+		"The Lines field may not be greater than 3 graphemes.",
+	})
+
+	// A family emoji is one grapheme made of several runes.
+	l1 := Length{Name: "احمد ولید", Code: "a", Title: "ü", Slug: "äbcd", Status: "👨‍👩‍👧", Summary: "ab"}
+	ck(NewLengthSchema(l1).Validate(), []string{
+		"The Name field may not be greater than 5 characters.",
+		"The Code field must be 2 characters.",
+		"The Title field must be between 2 and 4 characters.",
+		"The Slug field may not be greater than 4 bytes.",
+		"The Status field must be at least 2 graphemes.",
+		"The Summary field must be at least 3 bytes.",
+	})
+
+	ck(NewLengthSchema(Length{Name: "ab", Code: "ab", Title: "ab", Status: "🇵🇰🇵🇰🇵🇰🇵🇰"}).Validate(), []string{
+		"The Name field must be at least 3 characters.",
+		"The Status field may not be greater than 3 graphemes.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"length.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Handle string
+
+type Normalize struct {
+	Name    string `gov:"trim;collapse_spaces;required;max=10"`
+	Email   string `gov:"trim;lower;email"`
+	Phone   string `gov:"nfkc;numeric"`
+	City    string `gov:"nfc;same:Home"`
+	Home    string
+	Country string `gov:"upper;size=2;uppercase"`
+	Note    string `gov:"strip_control"`
+	Handle  Handle `gov:"trim;lower;alpha_num"`
+}
+
+func main() {
+	n0 := Normalize{
+		Name:    "  Jane \t  Doe ",
+		Email:   " Jane@Example.COM ",
+		Phone:   "０３００１２３",
+		City:    "Zürich",
+		Home:    "Zürich",
+		Country: "pk",
+		Note:    "line1\nline2\x00",
+		Handle:  " Gopher42 ",
+	}
+	// Without normalization the raw input fails.
+	ck(NewNormalizeSchema(n0).Validate(), []string{
+		"The Name field may not be greater than 10 characters.",
+		"The Phone field must contain only digits.",
+		"The City field must match the Home field.",
+		"The Country field must be uppercase.",
+		"The Handle field must contain only letters and digits.",
+	})
+
+	ck(ValidateAndNormalizeNormalize(&n0), []string(nil))
+	want := Normalize{
+		Name:    "Jane Doe",
+		Email:   "jane@example.com",
+		Phone:   "0300123",
+		City:    "Zürich",
+		Home:    "Zürich",
+		Country: "PK",
+		Note:    "line1line2",
+		Handle:  "gopher42",
+	}
+	if n0 != want {
+		panic("normalize.go: unexpected normalized value")
+	}
+
+	// The runtime package applies the registered normalizer.
+	n1 := Normalize{Name: " ", Email: " JANE@EXAMPLE.COM", Country: "pk"}
+	err := govader.ValidateAndNormalize(&n1)
+	ck(strings.Split(err.Error(), "\n"), []string{"The Name field is required."})
+	if n1.Email != "jane@example.com" {
+		panic("normalize.go: value not normalized by the registry")
+	}
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"normalize.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
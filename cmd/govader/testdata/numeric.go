@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Numeric struct {
+	Qty      int     `gov:"gt=0;lte=100"`
+	Discount float64 `gov:"gte=0;lt=0.5"`
+	Level    uint8   `gov:"max=300;min=1"`
+	Count    uint    `gov:"between=2,10"`
+	Score    int32   `gov:"gt=2.5;positive"`
+	Delta    int     `gov:"negative"`
+	Divisor  int     `gov:"non_zero"`
+	Step     int     `gov:"multiple_of=5"`
+	Weight   float64 `gov:"multiple_of=0.1;decimal_places=2"`
+	PIN      int     `gov:"digits=4"`
+	Account  uint64  `gov:"digits_between=6,8"`
+	Price    float32 `gov:"decimal_places=2"`
+	Third    float64 `gov:"min=1/3"`
+	Hex      float64 `gov:"max=0x10"`
+	Units    uint    `gov:"multiple_of=-5"`
+}
+
+func main() {
+	n0 := Numeric{
+		Qty:      100,
+		Discount: 0.25,
+		Level:    255,
+		Count:    10,
+		Score:    3,
+		Delta:    -1,
+		Divisor:  -7,
+		Step:     -15,
+		Weight:   0.3,
+		PIN:      -1234,
+		Account:  1234567,
+		Price:    9.99,
+		Third:    0.34,
+		Hex:      16,
+		Units:    10,
+	}
+	ck(NewNumericSchema(n0).Validate(), []string(nil))
+
+	n1 := Numeric{
+		Qty:      0,
+		Discount: 0.5,
+		Level:    0,
+		Count:    1,
+		Score:    -2,
+		Delta:    0,
+		Divisor:  0,
+		Step:     7,
+		Weight:   0.35,
+		PIN:      123,
+		Account:  123456789,
+		Price:    1.005,
+		Third:    0.33,
+		Hex:      17,
+		Units:    7,
+	}
+	ck(NewNumericSchema(n1).Validate(), []string{
+		"The Qty field must be greater than 0.",
+		"The Discount field must be less than 0.5.",
+		"The Level field must be at least 1.",
+		"The Count field must be between 2 and 10.",
+		"The Score field must be greater than 2.5.",
+		"The Score field must be positive.",
+		"The Delta field must be negative.",
+		"The Divisor field must not be zero.",
+		"The Step field must be a multiple of 5.",
+		"The Weight field must be a multiple of 0.1.",
+		"The PIN field must have 4 digits.",
+		"The Account field must have between 6 and 8 digits.",
+		"The Price field may not have more than 2 decimal places.",
+		"The Third field must be at least 0.3333333333333333.",
+		"The Hex field may not be greater than 16.",
+		"The Units field must be a multiple of -5.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"numeric.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+type Optional struct {
+	ID    int64  `gov:"sometimes;min=1;max=1000;required_with:Name"`
+	Age   int    `gov:"nullable;between=18,99;required_with:Name"`
+	Score int    `gov:"min=1"`
+	Name  string `gov:"required"`
+}
+
+func main() {
+	// Empty optional fields skip their constraint rules.
+	o0 := Optional{Score: 1, Name: "Jane"}
+	ck(NewOptionalSchema(o0).Validate(), []string{
+		"The Age field is required when Name is present.",
+	})
+
+	// Present optional fields are validated.
+	o1 := Optional{ID: 2000, Age: 10, Score: 1, Name: "Jane"}
+	ck(NewOptionalSchema(o1).Validate(), []string{
+		"The ID field may not be greater than 1000.",
+		"The Age field must be between 18 and 99.",
+	})
+
+	// Fields without modifiers fail when empty.
+	o2 := Optional{}
+	ck(NewOptionalSchema(o2).Validate(), []string{
+		"The Score field must be at least 1.",
+		"The Name field is required.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"optional.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Password struct {
+	Email    string `gov:"required"`
+	Name     string
+	Password string `gov:"password=min:10,classes:upper|lower|digit|symbol,max_repeat:2,not:Email|Name,breached"`
+	PIN      string `gov:"password=min:4,classes:digit"`
+}
+
+// breachedSet is an in-memory govader.BreachChecker.
+type breachedSet map[string]bool
+
+func (s breachedSet) Breached(ctx context.Context, password string) (bool, error) {
+	return s[password], nil
+}
+
+func main() {
+	svc := govader.Services{Breach: breachedSet{"Summer2024!x": true}}
+
+	p0 := Password{Email: "jane@example.com", Name: "Jane", Password: "Tr0ub4dor&3x", PIN: "4711"}
+	ck(NewPasswordSchema(p0).Validate(), []string(nil))
+	messages, err := NewPasswordSchema(p0).ValidateContext(context.Background(), svc)
+	if err != nil {
+		panic("password.go: " + err.Error())
+	}
+	ck(messages, []string(nil))
+
+	p1 := Password{Email: "jane@example.com", Name: "Jane", Password: "janeeee", PIN: "ab"}
+	ck(NewPasswordSchema(p1).Validate(), []string{
+		"The Password field does not meet the password policy: at least 10 characters, an uppercase letter, a digit, a symbol, no character repeated more than 2 times, not containing Email, not containing Name.",
+		"The PIN field does not meet the password policy: at least 4 characters, a digit.",
+	})
+
+	// The breach lookup only runs with a context.
+	p2 := Password{Email: "jane@example.com", Password: "Summer2024!x", PIN: "4711"}
+	ck(NewPasswordSchema(p2).Validate(), []string(nil))
+	messages, _ = NewPasswordSchema(p2).ValidateContext(context.Background(), svc)
+	ck(messages, []string{
+		"The Password field has appeared in a data breach, please choose a different password.",
+	})
+}
+
+func ck(got, want []string) {
+	if ok := reflect.DeepEqual(want, got); !ok {
+		panic(
+			"password.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(got, "\n"),
+		)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/ahmadwaleed/go-validation/govader"
+)
+
+type Registry struct {
+	ID   int64  `gov:"required"`
+	Name string `gov:"required"`
+	Age  int    `gov:"max=150"`
+}
+
+type unregistered struct{}
+
+func main() {
+	// Dispatch by dynamic type through the registry.
+	var v any = Registry{ID: 1, Name: "Jane"}
+	ck(govader.Validate(v), nil)
+	ck(govader.Validate(&Registry{ID: 1, Name: "Jane"}), nil)
+
+	err := govader.Validate(Registry{Age: 200})
+	var errs govader.Errors
+	if !errors.As(err, &errs) {
+		panic("registry.go: want govader.Errors, got " + err.Error())
+	}
+	ck(errs, []string{
+		"The ID field is required.",
+		"The Name field is required.",
+		"The Age field may not be greater than 150.",
+	})
+
+	if err := govader.Validate(unregistered{}); !errors.Is(err, govader.ErrNoSchema) {
+		panic("registry.go: want ErrNoSchema for unregistered type")
+	}
+}
+
+func ck(got error, want []string) {
+	var messages []string
+	if got != nil {
+		messages = got.(govader.Errors)
+	}
+	if ok := reflect.DeepEqual(want, messages); !ok {
+		panic(
+			"registry.go:\n" +
+				"want:\n" + strings.Join(want, "\n") +
+				"\n" +
+				"got:\n" + strings.Join(messages, "\n"),
+		)
+	}
+}
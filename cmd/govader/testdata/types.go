@@ -3,6 +3,7 @@ package main
 import (
 	"reflect"
 	"strings"
+	"time"
 )
 
 type Types struct {
@@ -19,6 +20,10 @@ type Types struct {
 	Float64 float64 `gov:"required"`
 	String  string  `gov:"required"`
 	// Boolean bool    `gov:"required"`
+	Time   time.Time         `gov:"required"`
+	Tags   []string          `gov:"required"`
+	Labels map[string]string `gov:"required"`
+	Parent *Types            `gov:"prohibited"`
 }
 
 func main() {
@@ -37,6 +42,19 @@ func main() {
 		"The Float32 field is required.",
 		"The Float64 field is required.",
 		"The String field is required.",
+		"The Time field is required.",
+		"The Tags field is required.",
+		"The Labels field is required.",
+	})
+
+	t2 := Types{
+		Int: 1, Int8: 1, Int16: 1, Int32: 1, Int64: 1, Uint8: 1, Uint16: 1, Uint32: 1, Uint64: 1,
+		Float32: 1, Float64: 1, String: "a", Time: time.Now(), Tags: []string{}, Labels: map[string]string{"a": "b"},
+		Parent: &t1,
+	}
+	ck(NewTypesSchema(t2).Validate(), []string{
+		"The Tags field is required.",
+		"The Parent field is prohibited.",
 	})
 }
 
@@ -27,15 +27,12 @@ func main() {
 	schema0 := NewUserSchema(u0)
 	ck(schema0.Validate(), []string(nil))
 
-	// Fails all rules.
+	// Fails all rules, value rules of ID are skipped once required failed.
 	u1 := User{}
 	schema1 := NewUserSchema(u1)
 	ck(schema1.Validate(), []string{
 		"The ID field is required.",
-		"The ID field must be at least 1.",
-		"The ID field must be between 1 and 1000.",
 		"The ID field must be different from the ID2 field.",
-		"The ID field must be of size 2.",
 		"The ID4 field is required when ID is not present.",
 		"The Name field is required.",
 		"The Email field must be a valid email address.",
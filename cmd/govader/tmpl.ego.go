@@ -25,12 +25,12 @@ func (tmpl *Template) Render(ctx context.Context, w io.Writer) {
 //line tmpl.ego:14
 	tmpl.Generator.GenImport()
 //line tmpl.ego:15
-	_, _ = io.WriteString(w, "\n)\n\n// presence\t        required\t            A rule without additional values\n// value_constraint\tmax:1000\t            A rule with a single key-value pair\n// conditional\t    required_if:Name=John\tA rule that depends on another field\n// range\tbetween:1,1000\tA rule that specifies a range of values\ntype (\n\t_Gov_PresenceValidator[T any]\t\t\tfunc(field string, value T) error\n\t_Gov_ValueConstraintValidator[T any]\tfunc(field string, value T, cond T) error\n\t_Gov_RangeValidator[T any]           \tfunc(field string, value T, min T, max T) error\n\t_Gov_ConditionalValidator     \t\t\tfunc(field1 string, value1 any, field2 string, value2 any, cond any) error\n)\n\ntype _Gov_Rule interface {\n\tValidate() error\n}\n\n// presence\t        required\t            A rule without additional values\ntype _Gov_RulePresence [T any]struct {\n\tField     string\n\tValue     T\n\tValidator _Gov_PresenceValidator[T]\n}\n\nfunc (r _Gov_RulePresence[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value)\n}\n\n// value_constraint\tmax:1000\t            A rule with a single key-value pair\ntype _Gov_RuleValueConstraint [T any]struct {\n\tName      string\n\tField     string\n\tValue     T\n\tCond      T\n\tValidator _Gov_ValueConstraintValidator[T]\n}\n\nfunc (r _Gov_RuleValueConstraint[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value, r.Cond)\n}\n\n// range\tbetween:1,1000\tA rule that specifies a range of values\ntype _Gov_RuleRange[T any] struct {\n\tName      string\n\tField     string\n\tValue     T\n\tMin       T\n\tMax       T\n\tValidator _Gov_RangeValidator[T]\n}\n\nfunc (r _Gov_RuleRange[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value, r.Min, r.Max)\n}\n\n// conditional\t    required_if:Name=John\tA rule that depends on another field\ntype _Gov_RuleConditional struct {\n\tName      string\n\tField1    string\n\tField2    string\n\tValue1    any\n\tValue2    any\n\tCond      any\n\tValidator _Gov_ConditionalValidator\n}\n\nfunc (r _Gov_RuleConditional) Validate() error {\n\treturn r.Validator(r.Field1, r.Value1, r.Field2, r.Value2, r.Cond)\n}\n\n")
-//line tmpl.ego:85
+	_, _ = io.WriteString(w, "\n)\n\n// presence\t        required\t            A rule without additional values\n// value_constraint\tmax:1000\t            A rule with a single key-value pair\n// conditional\t    required_if:Name=John\tA rule that depends on another field\n// range\tbetween:1,1000\tA rule that specifies a range of values\ntype (\n\t_Gov_PresenceValidator[T any]\t\t\tfunc(field string, value T) error\n\t_Gov_ValueConstraintValidator[T any]\tfunc(field string, value T, cond T) error\n\t_Gov_RangeValidator[T any]           \tfunc(field string, value T, min T, max T) error\n\t_Gov_ConditionalValidator     \t\t\tfunc(field1 string, value1 any, fields []string, values []any, conds []string) error\n)\n\ntype _Gov_Rule interface {\n\tValidate() error\n\tapplies(groups []string) bool\n}\n\n// _Gov_Groups holds the validation groups of a rule.\ntype _Gov_Groups []string\n\n// applies reports whether the rule runs when validating the given groups,\n// rules without groups apply always.\nfunc (g _Gov_Groups) applies(groups []string) bool {\n\tif len(g) == 0 {\n\t\treturn true\n\t}\n\tfor _, group := range groups {\n\t\tif slices.Contains(g, group) {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n\n// presence\t        required\t            A rule without additional values\ntype _Gov_RulePresence [T any]struct {\n\t_Gov_Groups\n\tName      string\n\tField     string\n\tValue     T\n\tValidator _Gov_PresenceValidator[T]\n}\n\nfunc (r _Gov_RulePresence[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value)\n}\n\n// value_constraint\tmax:1000\t            A rule with a single key-value pair\ntype _Gov_RuleValueConstraint [T any]struct {\n\t_Gov_Groups\n\tName      string\n\tField     string\n\tValue     T\n\tCond      T\n\tValidator _Gov_ValueConstraintValidator[T]\n}\n\nfunc (r _Gov_RuleValueConstraint[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value, r.Cond)\n}\n\n// range\tbetween:1,1000\tA rule that specifies a range of values\ntype _Gov_RuleRange[T any] struct {\n\t_Gov_Groups\n\tName      string\n\tField     string\n\tValue     T\n\tMin       T\n\tMax       T\n\tValidator _Gov_RangeValidator[T]\n}\n\nfunc (r _Gov_RuleRange[T]) Validate() error {\n\treturn r.Validator(r.Field, r.Value, r.Min, r.Max)\n}\n\n// conditional\t    required_if:Name=John\tA rule that depends on another field\ntype _Gov_RuleConditional struct {\n\t_Gov_Groups\n\tName      string\n\tField1    string\n\tFields    []string\n\tValue1    any\n\tValues    []any\n\tConds     []string\n\tValidator _Gov_ConditionalValidator\n}\n\nfunc (r _Gov_RuleConditional) Validate() error {\n\treturn r.Validator(r.Field1, r.Value1, r.Fields, r.Values, r.Conds)\n}\n\nfunc (r _Gov_RuleConditional) dependsOn(fields []string) bool {\n\tfor _, field := range r.Fields {\n\t\tif slices.Contains(fields, field) {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n\n// _Gov_Present reports whether v holds a non-zero value, slices and maps\n// must not be empty.\nfunc _Gov_Present(v any) bool {\n\tif v == nil {\n\t\treturn false\n\t}\n\tif rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {\n\t\treturn rv.Len() > 0\n\t}\n\treturn !reflect.ValueOf(v).IsZero()\n}\n\n// _Gov_Graphemes counts the user-perceived characters of s. It approximates\n// extended grapheme clusters: CRLF is a cluster, marks, variation selectors,\n// skin tones, tags and zero width joiner sequences extend a cluster, two\n// regional indicators form a flag and Hangul jamo compose syllables. Other\n// rules of UAX #29, e.g. prepended marks and Indic conjuncts, are not\n// applied.\nfunc _Gov_Graphemes(s string) (n int) {\n\tvar prev rune\n\tfor _, r := range s {\n\t\textend := unicode.Is(unicode.M, r) || r == '\\u200d' || prev == '\\u200d' ||\n\t\t\tr >= 0xfe00 && r <= 0xfe0f || r >= 0x1f3fb && r <= 0x1f3ff || r >= 0xe0020 && r <= 0xe007f\n\t\tif regional := func(r rune) bool { return r >= 0x1f1e6 && r <= 0x1f1ff }; regional(r) && regional(prev) {\n\t\t\textend, r = true, 0 // The flag is complete.\n\t\t}\n\t\tswitch p, c := _Gov_Hangul(prev), _Gov_Hangul(r); {\n\t\tcase prev == '\\r' && r == '\\n':\n\t\t\textend = true\n\t\tcase prev == '\\r' || prev == '\\n':\n\t\t\textend = false // Nothing extends a line break.\n\t\tcase p == \"L\" && (c == \"L\" || c == \"V\" || c == \"LV\" || c == \"LVT\"),\n\t\t\t(p == \"V\" || p == \"LV\") && (c == \"V\" || c == \"T\"),\n\t\t\t(p == \"T\" || p == \"LVT\") && c == \"T\":\n\t\t\textend = true\n\t\t}\n\t\tif !extend {\n\t\t\tn++\n\t\t}\n\t\tprev = r\n\t}\n\treturn n\n}\n\n// _Gov_Hangul returns the Hangul syllable type of r: L, V and T for leading\n// consonant, vowel and trailing consonant jamo, LV and LVT for precomposed\n// syllables.\nfunc _Gov_Hangul(r rune) string {\n\tswitch {\n\tcase r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:\n\t\treturn \"L\"\n\tcase r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:\n\t\treturn \"V\"\n\tcase r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:\n\t\treturn \"T\"\n\tcase r >= 0xac00 && r <= 0xd7a3 && (r-0xac00)%28 == 0:\n\t\treturn \"LV\"\n\tcase r >= 0xac00 && r <= 0xd7a3:\n\t\treturn \"LVT\"\n\t}\n\treturn \"\"\n}\n\n// _Gov_In reports whether v formatted as string is one of conds.\nfunc _Gov_In(v any, conds []string) bool {\n\treturn slices.Contains(conds, fmt.Sprint(v))\n}\n\n// custom\tsku\tA rule implemented by a function of the package\ntype _Gov_RuleCustom struct {\n\t_Gov_Groups\n\tName  string\n\tField string\n\tArgs  string\n\tFunc  func() error\n}\n\nfunc (r _Gov_RuleCustom) Validate() error {\n\terr := r.Func()\n\tif err == nil {\n\t\treturn nil\n\t}\n\tif _, ok := _Gov_Schema_message[r.Name]; ok {\n\t\treturn _Gov_Error(r.Name, r.Field, r.Args, \"\", \"\")\n\t}\n\treturn err\n}\n\n// in\tin=active,pending\tA rule restricting the field to a list of values\ntype _Gov_RuleIn struct {\n\t_Gov_Groups\n\tName   string\n\tField  string\n\tValues string\n\tFunc   func() bool\n}\n\nfunc (r _Gov_RuleIn) Validate() error {\n\tif !r.Func() {\n\t\treturn _Gov_Error(r.Name, r.Field, r.Values, \"\", \"\")\n\t}\n\treturn nil\n}\n\n// numeric\tgt=0\tA comparison on a numeric field\ntype _Gov_RuleNumeric struct {\n\t_Gov_Groups\n\tName   string\n\tField  string\n\tValue1 string\n\tValue2 string\n\tFunc   func() bool\n}\n\nfunc (r _Gov_RuleNumeric) Validate() error {\n\tif !r.Func() {\n\t\treturn _Gov_Error(r.Name, r.Field, r.Value1, \"\", r.Value2)\n\t}\n\treturn nil\n}\n\n// _Gov_DecimalPlaces counts the digits after the decimal point of s.\nfunc _Gov_DecimalPlaces(s string) int {\n\t_, frac, _ := strings.Cut(s, \".\")\n\treturn len(frac)\n}\n\n// net\tin_prefix=10.0.0.0/8\tA rule on a netip, net.IP or *url.URL field\ntype _Gov_RuleNet struct {\n\t_Gov_Groups\n\tName  string\n\tField string\n\tValue string\n\tFunc  func() bool\n}\n\nfunc (r _Gov_RuleNet) Validate() error {\n\tif !r.Func() {\n\t\treturn _Gov_Error(r.Name, r.Field, r.Value, \"\", \"\")\n\t}\n\treturn nil\n}\n\n// collection\tunique=SKU\tA rule on the elements of a slice field\ntype _Gov_RuleCollection struct {\n\t_Gov_Groups\n\tName  string\n\tField string\n\tKey   string // Field of the elements, e.g. SKU of unique=SKU.\n\tValue string\n\tDup   func() (i, j int) // Indexes of the first duplicate, for distinct and unique.\n\tFunc  func() bool\n}\n\nfunc (r _Gov_RuleCollection) Validate() error {\n\tif r.Dup == nil {\n\t\tif !r.Func() {\n\t\t\treturn _Gov_Error(r.Name, r.Field, r.Value, r.Key, \"\")\n\t\t}\n\t\treturn nil\n\t}\n\ti, j := r.Dup()\n\tif j == -1 {\n\t\treturn nil\n\t}\n\tfield1, field2 := fmt.Sprintf(\"%s[%d]\", r.Field, j), fmt.Sprintf(\"%s[%d]\", r.Field, i)\n\tif r.Key != \"\" {\n\t\tfield1, field2 = field1+\".\"+r.Key, field2+\".\"+r.Key\n\t}\n\treturn _Gov_Error(\"distinct\", field1, \"\", field2, \"\")\n}\n\n// _Gov_Duplicate returns the indexes i < j of the first of n elements\n// whose key equals the key of an earlier element, or -1, -1. Nil keys are\n// skipped.\nfunc _Gov_Duplicate(n int, key func(i int) any) (int, int) {\n\tseen := make(map[any]int, n)\n\tfor j := range n {\n\t\tk := key(j)\n\t\tif k == nil {\n\t\t\tcontinue\n\t\t}\n\t\tif i, ok := seen[k]; ok {\n\t\t\treturn i, j\n\t\t}\n\t\tseen[k] = j\n\t}\n\treturn -1, -1\n}\n\n// _Gov_Sum sums the values of n elements.\nfunc _Gov_Sum[T int64 | uint64 | float64](n int, value func(i int) T) (sum T) {\n\tfor i := range n {\n\t\tsum += value(i)\n\t}\n\treturn sum\n}\n\n// password\tpassword=min:12,classes:upper|digit\tA password policy listing the failed requirements\ntype _Gov_RulePassword struct {\n\t_Gov_Groups\n\tField     string\n\tValue     string\n\tMin       int\n\tClasses   []string\n\tMaxRepeat int\n\tNot       []string // Fields the password must not contain.\n\tValues    []string // Values of the Not fields.\n}\n\nfunc (r _Gov_RulePassword) Validate() error {\n\tvar failed []string\n\trunes := []rune(r.Value)\n\tif len(runes) < r.Min {\n\t\tfailed = append(failed, _Gov_Message(\"password_min\", fmt.Sprint(r.Min)))\n\t}\n\tfor _, class := range r.Classes {\n\t\tif !slices.ContainsFunc(runes, _Gov_PasswordClasses[class]) {\n\t\t\tfailed = append(failed, _Gov_Message(\"password_\"+class, \"\"))\n\t\t}\n\t}\n\tif r.MaxRepeat > 0 {\n\t\trun := 1\n\t\tfor i := 1; i < len(runes); i++ {\n\t\t\tif runes[i] == runes[i-1] {\n\t\t\t\trun++\n\t\t\t} else {\n\t\t\t\trun = 1\n\t\t\t}\n\t\t\tif run > r.MaxRepeat {\n\t\t\t\tfailed = append(failed, _Gov_Message(\"password_max_repeat\", fmt.Sprint(r.MaxRepeat)))\n\t\t\t\tbreak\n\t\t\t}\n\t\t}\n\t}\n\tpassword := strings.ToLower(r.Value)\n\tfor i, v := range r.Values {\n\t\t// An email address is also checked by its local part.\n\t\tlocal, _, _ := strings.Cut(v, \"@\")\n\t\tif v != \"\" && (strings.Contains(password, strings.ToLower(v)) || local != \"\" && strings.Contains(password, strings.ToLower(local))) {\n\t\t\tfailed = append(failed, _Gov_Message(\"password_not\", r.Not[i]))\n\t\t}\n\t}\n\tif len(failed) > 0 {\n\t\treturn _Gov_Error(\"password\", r.Field, strings.Join(failed, \", \"), \"\", \"\")\n\t}\n\treturn nil\n}\n\nfunc (r _Gov_RulePassword) dependsOn(fields []string) bool {\n\tfor _, field := range r.Not {\n\t\tif slices.Contains(fields, field) {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n\n// _Gov_PasswordClasses maps the character classes of the password rule to\n// their predicate.\nvar _Gov_PasswordClasses = map[string]func(rune) bool{\n\t\"upper\":  unicode.IsUpper,\n\t\"lower\":  unicode.IsLower,\n\t\"digit\":  unicode.IsDigit,\n\t\"symbol\": func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) },\n}\n\n// _Gov_Message returns the message fragment of key, e.g. a failed\n// requirement listed by the password message.\nfunc _Gov_Message(key, value string) string {\n\treturn strings.TrimSuffix(_Gov_Error(key, \"\", value, \"\", \"\").Error(), \".\")\n}\n\n// expr\texpr=End > Start\tA Go expression over the fields of the struct\ntype _Gov_RuleExpr struct {\n\t_Gov_Groups\n\tField string\n\tExpr  string\n\tDeps  []string\n\tFunc  func() bool\n}\n\nfunc (r _Gov_RuleExpr) Validate() error {\n\tif !r.Func() {\n\t\treturn _Gov_Error(\"expr\", r.Field, r.Expr, \"\", \"\")\n\t}\n\treturn nil\n}\n\nfunc (r _Gov_RuleExpr) dependsOn(fields []string) bool {\n\tfor _, dep := range r.Deps {\n\t\tif slices.Contains(fields, dep) {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n\n// compare\tgte_field:MinPrice\tA rule ordering the field against another field\ntype _Gov_RuleCompare struct {\n\t_Gov_Groups\n\tName   string\n\tField1 string\n\tField2 string\n\tFunc   func() bool\n}\n\nfunc (r _Gov_RuleCompare) Validate() error {\n\tif !r.Func() {\n\t\treturn _Gov_Error(r.Name, r.Field1, \"\", r.Field2, \"\")\n\t}\n\treturn nil\n}\n\nfunc (r _Gov_RuleCompare) dependsOn(fields []string) bool {\n\treturn slices.Contains(fields, r.Field2)\n}\n\n// struct\tValidateStruct\tA struct-level validation hook of the type\ntype _Gov_RuleStruct struct {\n\t_Gov_Groups\n\tHook func(r *govader.Reporter)\n}\n\nfunc (r _Gov_RuleStruct) Validate() error {\n\treturn r.validate(func(string) bool { return true })\n}\n\n// validateFields keeps the hook errors reported against the given field\n// paths or their elements.\nfunc (r _Gov_RuleStruct) validateFields(fields []string) error {\n\treturn r.validate(func(path string) bool {\n\t\treturn slices.ContainsFunc(fields, func(field string) bool {\n\t\t\treturn path == field || strings.HasPrefix(path, field+\".\") || strings.HasPrefix(path, field+\"[\")\n\t\t})\n\t})\n}\n\nfunc (r _Gov_RuleStruct) validate(keep func(path string) bool) error {\n\tvar rep govader.Reporter\n\tr.Hook(&rep)\n\tvar errs []error\n\tfor _, e := range rep.Errors() {\n\t\tif !keep(e.Field) {\n\t\t\tcontinue\n\t\t}\n\t\tif e.Message == \"\" {\n\t\t\terrs = append(errs, _Gov_Error(e.Code, e.Field, \"\", \"\", \"\"))\n\t\t} else {\n\t\t\terrs = append(errs, e)\n\t\t}\n\t}\n\treturn errors.Join(errs...)\n}\n\n// dependsOn reports the hook may depend on any field, its errors are\n// filtered by validateFields.\nfunc (r _Gov_RuleStruct) dependsOn(fields []string) bool {\n\treturn len(fields) > 0\n}\n\n// group\tone_of_required=Email,Phone\tA constraint on a group of fields\ntype _Gov_RuleGroup struct {\n\t_Gov_Groups\n\tName   string\n\tFields []string\n\tValues []any\n}\n\n// Validate reports the failed constraint against every participating field.\nfunc (r _Gov_RuleGroup) Validate() error {\n\tvar present []string\n\tfor i, v := range r.Values {\n\t\tif _Gov_Present(v) {\n\t\t\tpresent = append(present, r.Fields[i])\n\t\t}\n\t}\n\tfields := r.Fields\n\tswitch r.Name {\n\tcase \"one_of_required\":\n\t\tif len(present) > 0 {\n\t\t\treturn nil\n\t\t}\n\tcase \"exactly_one_of\":\n\t\tif len(present) == 1 {\n\t\t\treturn nil\n\t\t}\n\tcase \"mutually_exclusive\":\n\t\tif len(present) < 2 {\n\t\t\treturn nil\n\t\t}\n\t\tfields = present\n\t}\n\tvar errs []error\n\tfor _, field := range fields {\n\t\tothers := slices.DeleteFunc(slices.Clone(fields), func(f string) bool { return f == field })\n\t\terrs = append(errs, _Gov_Error(r.Name, field, \"\", strings.Join(others, \", \"), \"\"))\n\t}\n\treturn errors.Join(errs...)\n}\n\nfunc (r _Gov_RuleGroup) dependsOn(fields []string) bool {\n\tfor _, field := range r.Fields {\n\t\tif slices.Contains(fields, field) {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n\n// service\tunique=users.email\tA rule backed by an injected service\ntype _Gov_RuleUnique struct {\n\t_Gov_Groups\n\tField  string\n\tValue  any\n\tTable  string\n\tColumn string\n}\n\n// Validate always passes, the rule needs I/O and only runs with a context.\nfunc (r _Gov_RuleUnique) Validate() error {\n\treturn nil\n}\n\nfunc (r _Gov_RuleUnique) validateContext(ctx context.Context, svc govader.Services) (failed, err error) {\n\tif svc.Unique == nil {\n\t\treturn nil, fmt.Errorf(\"govader: no UniqueChecker for rule unique of field %s\", r.Field)\n\t}\n\tok, err := svc.Unique.Unique(ctx, r.Table, r.Column, r.Value)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tif !ok {\n\t\treturn _Gov_Error(\"unique\", r.Field, \"\", \"\", \"\"), nil\n\t}\n\treturn nil, nil\n}\n\n// service\temail=mx\tAn email rule checking the domain accepts mail\ntype _Gov_RuleMX struct {\n\t_Gov_Groups\n\tField string\n\tValue string\n}\n\n// Validate always passes, the rule needs I/O and only runs with a context.\nfunc (r _Gov_RuleMX) Validate() error {\n\treturn nil\n}\n\nfunc (r _Gov_RuleMX) validateContext(ctx context.Context, svc govader.Services) (failed, err error) {\n\tif svc.MX == nil {\n\t\treturn nil, fmt.Errorf(\"govader: no MXChecker for rule email of field %s\", r.Field)\n\t}\n\tat := strings.LastIndex(r.Value, \"@\")\n\tif at == -1 {\n\t\treturn nil, nil // Not an address, reported by the email rule.\n\t}\n\tdomain := strings.ToLower(strings.TrimSuffix(r.Value[at+1:], \">\"))\n\tok, err := svc.MX.HasMX(ctx, domain)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tif !ok {\n\t\treturn _Gov_Error(\"email_mx\", r.Field, domain, \"\", \"\"), nil\n\t}\n\treturn nil, nil\n}\n\n// service\tpassword=breached\tA password rule checking the password has not been breached\ntype _Gov_RuleBreached struct {\n\t_Gov_Groups\n\tField string\n\tValue string\n}\n\n// Validate always passes, the rule needs I/O and only runs with a context.\nfunc (r _Gov_RuleBreached) Validate() error {\n\treturn nil\n}\n\nfunc (r _Gov_RuleBreached) validateContext(ctx context.Context, svc govader.Services) (failed, err error) {\n\tif svc.Breach == nil {\n\t\treturn nil, fmt.Errorf(\"govader: no BreachChecker for rule password of field %s\", r.Field)\n\t}\n\tbreached, err := svc.Breach.Breached(ctx, r.Value)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tif breached {\n\t\treturn _Gov_Error(\"password_breached\", r.Field, \"\", \"\", \"\"), nil\n\t}\n\treturn nil, nil\n}\n\n// _Gov_PresenceRule and _Gov_ValueRule mark rule kinds, value rules of a\n// field are skipped once one of its required rules failed.\ntype _Gov_PresenceRule interface {\n\t_Gov_Rule\n\trequired() bool\n}\n\ntype _Gov_ValueRule interface {\n\t_Gov_Rule\n\tvalue()\n}\n\n// _Gov_ServiceRule is implemented by rules which need I/O, failed holds the\n// validation error and err the error of the service.\ntype _Gov_ServiceRule interface {\n\t_Gov_Rule\n\tvalidateContext(ctx context.Context, svc govader.Services) (failed, err error)\n}\n\n// _Gov_CrossFieldRule is implemented by rules which depend on other fields.\ntype _Gov_CrossFieldRule interface {\n\t_Gov_Rule\n\tdependsOn(fields []string) bool\n}\n\n// _Gov_PartialRule is implemented by rules which report errors against\n// several fields, partial validation only keeps the errors of its fields.\ntype _Gov_PartialRule interface {\n\t_Gov_Rule\n\tvalidateFields(fields []string) error\n}\n\nfunc (r _Gov_RulePresence[T]) required() bool { return r.Name == \"required\" }\nfunc (r _Gov_RuleConditional) required() bool { return strings.HasPrefix(r.Name, \"required_\") }\n\nfunc (r _Gov_RuleValueConstraint[T]) value() {}\nfunc (r _Gov_RuleRange[T]) value()           {}\nfunc (r _Gov_RuleUnique) value()             {}\nfunc (r _Gov_RuleMX) value()                 {}\nfunc (r _Gov_RuleCustom) value()             {}\nfunc (r _Gov_RuleIn) value()                 {}\nfunc (r _Gov_RuleNumeric) value()            {}\nfunc (r _Gov_RuleNet) value()                {}\nfunc (r _Gov_RuleCollection) value()         {}\nfunc (r _Gov_RulePassword) value()           {}\nfunc (r _Gov_RuleBreached) value()           {}\n\n// _Gov_Field groups the rules of a single struct field.\ntype _Gov_Field struct {\n\tName      string\n\tBail      bool // Stop at the first failing rule of the field.\n\tSometimes bool // Skip all rules of the field when it is empty.\n\tNullable  bool // Skip value rules of the field when it is empty.\n\tEmpty     bool // The field holds its zero value.\n\tExclude   bool // Skip all rules of the field, see exclude_if.\n\tRules     []_Gov_Rule\n}\n\nfunc _Gov_IsZero[T comparable](v T) bool {\n\tvar zero T\n\treturn v == zero\n}\n\n// _Gov_Options controls which rules _Gov_Validate runs.\ntype _Gov_Options struct {\n\tMaxErrors int      // Stop after MaxErrors messages if greater than zero.\n\tGroups    []string // Validation groups to run besides rules without groups.\n\tPartial   bool     // Validate only Fields and cross-field rules depending on them.\n\tFields    []string\n\tContext   context.Context // Run rules which need I/O if not nil.\n\tServices  govader.Services\n}\n\n// _Gov_Validate runs the rules of each field and collects error messages.\n// It stops and returns the error if the context is done or a service fails.\nfunc _Gov_Validate(fields []_Gov_Field, opts _Gov_Options) (messages []string, err error) {\n\tfor _, field := range fields {\n\t\tif field.Exclude || field.Sometimes && field.Empty {\n\t\t\tcontinue\n\t\t}\n\t\tabsent := field.Nullable && field.Empty // Presence rule of the field failed.\n\t\ttouched := !opts.Partial || slices.Contains(opts.Fields, field.Name)\n\t\tfor _, rule := range field.Rules {\n\t\t\tif !rule.applies(opts.Groups) {\n\t\t\t\tcontinue\n\t\t\t}\n\t\t\tif !touched {\n\t\t\t\tif cr, ok := rule.(_Gov_CrossFieldRule); !ok || !cr.dependsOn(opts.Fields) {\n\t\t\t\t\tcontinue\n\t\t\t\t}\n\t\t\t}\n\t\t\tif _, ok := rule.(_Gov_ValueRule); ok && absent {\n\t\t\t\tcontinue\n\t\t\t}\n\t\t\tif opts.Context != nil {\n\t\t\t\tif err := opts.Context.Err(); err != nil {\n\t\t\t\t\treturn messages, err\n\t\t\t\t}\n\t\t\t}\n\t\t\tvar failed error\n\t\t\tif sr, ok := rule.(_Gov_ServiceRule); ok {\n\t\t\t\tif opts.Context == nil {\n\t\t\t\t\tcontinue\n\t\t\t\t}\n\t\t\t\tif failed, err = sr.validateContext(opts.Context, opts.Services); err != nil {\n\t\t\t\t\treturn messages, err\n\t\t\t\t}\n\t\t\t} else if pr, ok := rule.(_Gov_PartialRule); ok && opts.Partial {\n\t\t\t\tfailed = pr.validateFields(opts.Fields)\n\t\t\t} else {\n\t\t\t\tfailed = rule.Validate()\n\t\t\t}\n\t\t\tif failed == nil {\n\t\t\t\tcontinue\n\t\t\t}\n\t\t\terrs := []error{failed}\n\t\t\tif joined, ok := failed.(interface{ Unwrap() []error }); ok {\n\t\t\t\terrs = joined.Unwrap()\n\t\t\t}\n\t\t\tfor _, err := range errs {\n\t\t\t\tmessages = append(messages, err.Error())\n\t\t\t\tif opts.MaxErrors > 0 && len(messages) >= opts.MaxErrors {\n\t\t\t\t\treturn messages, nil\n\t\t\t\t}\n\t\t\t}\n\t\t\tif field.Bail {\n\t\t\t\tbreak\n\t\t\t}\n\t\t\tif pr, ok := rule.(_Gov_PresenceRule); ok && pr.required() {\n\t\t\t\tabsent = true\n\t\t\t}\n\t\t}\n\t}\n\treturn messages, nil\n}\n\n// _Gov_UnknownFields reports the paths which are not fields of the struct.\nfunc _Gov_UnknownFields(names, paths []string) (messages []string) {\n\tfor _, path := range paths {\n\t\tif !slices.Contains(names, path) {\n\t\t\tmessages = append(messages, _Gov_Error(\"unknown_field\", path, \"\", \"\", \"\").Error())\n\t\t}\n\t}\n\treturn messages\n}\n\n")
+//line tmpl.ego:758
 	tmpl.Generator.Generate()
-//line tmpl.ego:86
+//line tmpl.ego:759
 	_, _ = io.WriteString(w, "\n\n")
-//line tmpl.ego:87
+//line tmpl.ego:760
 }
 
 var _ fmt.Stringer
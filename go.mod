@@ -5,6 +5,7 @@ go 1.23.4
 require (
 	github.com/spf13/cast v1.7.1
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.21.0
 	golang.org/x/tools v0.28.0
 )
 
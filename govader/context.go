@@ -0,0 +1,93 @@
+package govader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// UniqueChecker reports whether value is not yet taken in the column of
+// a table, it backs rules such as unique=users.email.
+type UniqueChecker interface {
+	Unique(ctx context.Context, table, column string, value any) (bool, error)
+}
+
+// MXChecker reports whether domain has mail exchange records, it backs
+// the email=mx rule.
+type MXChecker interface {
+	HasMX(ctx context.Context, domain string) (bool, error)
+}
+
+// BreachChecker reports whether password appeared in a known data breach,
+// it backs the password=breached rule.
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// ResolverMX is a MXChecker looking up records with Resolver, or with
+// net.DefaultResolver if nil.
+type ResolverMX struct {
+	Resolver *net.Resolver
+}
+
+func (r ResolverMX) HasMX(ctx context.Context, domain string) (bool, error) {
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	records, err := resolver.LookupMX(ctx, domain)
+	if dnsErr := (*net.DNSError)(nil); errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(records) > 0, nil
+}
+
+// Services holds the dependencies of rules which need I/O.
+type Services struct {
+	Unique UniqueChecker
+	MX     MXChecker
+	Breach BreachChecker
+}
+
+// ContextValidatable is implemented by schemas supporting rules which
+// need I/O. Such rules only run when validating with a context.
+type ContextValidatable interface {
+	Validatable
+	ValidateContext(ctx context.Context, svc Services) ([]string, error)
+}
+
+// Validator validates values through the registry, passing its services
+// to the rules which need them.
+type Validator struct {
+	Services Services
+}
+
+// ValidateContext validates v against its registered schema. It stops and
+// returns the context error if ctx is done, or the error of a service.
+func (vr *Validator) ValidateContext(ctx context.Context, v any) error {
+	schema, ok := Lookup(v)
+	if !ok {
+		return fmt.Errorf("%w for %T", ErrNoSchema, v)
+	}
+	cs, ok := schema.(ContextValidatable)
+	if !ok {
+		return fmt.Errorf("govader: schema for %T does not support context", v)
+	}
+	messages, err := cs.ValidateContext(ctx, vr.Services)
+	if err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		return Errors(messages)
+	}
+	return nil
+}
+
+// ValidateContext validates v against its registered schema without services.
+func ValidateContext(ctx context.Context, v any) error {
+	return new(Validator).ValidateContext(ctx, v)
+}
@@ -0,0 +1,125 @@
+package govader
+
+import (
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Email reports whether s is a RFC 5322 address with a dotted domain and
+// returns the lower-cased domain. opts are the options of the email rule,
+// strict rejects display names and allow_idn accepts non-ASCII addresses.
+func Email(s string, opts []string) (domain string, ok bool) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || slices.Contains(opts, "strict") && (addr.Name != "" || strings.HasSuffix(s, ">")) {
+		return "", false
+	}
+	domain = strings.ToLower(addr.Address[strings.LastIndex(addr.Address, "@")+1:])
+	nonASCII := strings.IndexFunc(addr.Address, func(r rune) bool { return r > unicode.MaxASCII }) != -1
+	return domain, strings.Contains(domain, ".") && (!nonASCII || slices.Contains(opts, "allow_idn"))
+}
+
+// EmailDomain reports whether domain passes the allow_domains and
+// deny_domains options of the email rule, e.g. deny_domains:a.com|b.com.
+func EmailDomain(domain string, opts []string) bool {
+	for _, opt := range opts {
+		if domains, ok := strings.CutPrefix(opt, "allow_domains:"); ok && !slices.Contains(strings.Split(strings.ToLower(domains), "|"), domain) {
+			return false
+		}
+		if domains, ok := strings.CutPrefix(opt, "deny_domains:"); ok && slices.Contains(strings.Split(strings.ToLower(domains), "|"), domain) {
+			return false
+		}
+	}
+	return true
+}
+
+// URL reports whether s is an absolute URL with a host or opaque part,
+// e.g. mailto:a@example.com.
+func URL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+}
+
+// HTTPURL reports whether s is a http or https URL with a host.
+func HTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// UUID reports whether s is a hyphenated UUID, e.g.
+// 123e4567-e89b-12d3-a456-426614174000, of the given version digit if
+// version is not empty.
+func UUID(s, version string) bool {
+	ok := len(s) == 36
+	for i := 0; ok && i < len(s); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			ok = s[i] == '-'
+		} else {
+			ok = strings.IndexByte("0123456789abcdefABCDEF", s[i]) != -1
+		}
+	}
+	return ok && (version == "" || s[14] == version[0])
+}
+
+// ULID reports whether s is 26 characters of Crockford's base32, the
+// first of which encodes at most 3 bits.
+func ULID(s string) bool {
+	ok := len(s) == 26 && s[0] <= '7'
+	for i := 0; ok && i < len(s); i++ {
+		ok = strings.IndexByte("0123456789ABCDEFGHJKMNPQRSTVWXYZabcdefghjkmnpqrstvwxyz", s[i]) != -1
+	}
+	return ok
+}
+
+// every reports whether every rune of s satisfies f.
+func every(s string, f func(r rune) bool) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return !f(r) }) == -1
+}
+
+// Alpha reports whether s only holds letters.
+func Alpha(s string) bool {
+	return every(s, unicode.IsLetter)
+}
+
+// AlphaNum reports whether s only holds letters and digits.
+func AlphaNum(s string) bool {
+	return every(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+}
+
+// AlphaDash reports whether s only holds letters, digits, dashes and
+// underscores.
+func AlphaDash(s string) bool {
+	return every(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' })
+}
+
+// Numeric reports whether s only holds the ASCII digits 0 to 9.
+func Numeric(s string) bool {
+	return every(s, func(r rune) bool { return r >= '0' && r <= '9' })
+}
+
+// ASCII reports whether s only holds ASCII characters.
+func ASCII(s string) bool {
+	return every(s, func(r rune) bool { return r <= unicode.MaxASCII })
+}
+
+// Printable reports whether s only holds printable characters.
+func Printable(s string) bool {
+	return every(s, unicode.IsPrint)
+}
+
+// Lowercase reports whether s holds no upper or title case letters.
+func Lowercase(s string) bool {
+	return every(s, func(r rune) bool { return !unicode.IsUpper(r) && !unicode.IsTitle(r) })
+}
+
+// Uppercase reports whether s holds no lower or title case letters.
+func Uppercase(s string) bool {
+	return every(s, func(r rune) bool { return !unicode.IsLower(r) && !unicode.IsTitle(r) })
+}
+
+// NoWhitespace reports whether s holds no white space.
+func NoWhitespace(s string) bool {
+	return every(s, func(r rune) bool { return !unicode.IsSpace(r) })
+}
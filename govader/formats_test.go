@@ -0,0 +1,49 @@
+package govader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__Formats(t *testing.T) {
+	domain, ok := Email("Root <root@Example.com>", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", domain)
+	_, ok = Email("Root <root@example.com>", []string{"strict"})
+	assert.False(t, ok)
+	_, ok = Email("root@localhost", nil)
+	assert.False(t, ok)
+	_, ok = Email("jörg@example.com", nil)
+	assert.False(t, ok)
+	_, ok = Email("jörg@example.com", []string{"allow_idn"})
+	assert.True(t, ok)
+	assert.True(t, EmailDomain("example.com", []string{"allow_domains:Example.com|example.org"}))
+	assert.False(t, EmailDomain("example.net", []string{"allow_domains:example.com"}))
+	assert.False(t, EmailDomain("example.com", []string{"deny_domains:example.com"}))
+
+	assert.True(t, URL("mailto:root@example.com"))
+	assert.False(t, URL("example.com"))
+	assert.True(t, HTTPURL("https://example.com/a"))
+	assert.False(t, HTTPURL("ftp://example.com"))
+	assert.True(t, UUID("123e4567-e89b-12d3-a456-426614174000", ""))
+	assert.True(t, UUID("123e4567-e89b-12d3-a456-426614174000", "1"))
+	assert.False(t, UUID("123e4567-e89b-12d3-a456-426614174000", "4"))
+	assert.False(t, UUID("123e4567e89b12d3a456426614174000", ""))
+	assert.True(t, ULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	assert.False(t, ULID("81ARZ3NDEKTSV4RRFFQ69G5FAV")) // Overflows 128 bits.
+
+	assert.True(t, Alpha("Straße"))
+	assert.False(t, Alpha("a1"))
+	assert.True(t, AlphaNum("a1"))
+	assert.True(t, AlphaDash("a-1_b"))
+	assert.False(t, AlphaDash("a b"))
+	assert.True(t, Numeric("0123"))
+	assert.False(t, Numeric("١٢٣"))
+	assert.False(t, ASCII("é"))
+	assert.False(t, Printable("a\tb"))
+	assert.True(t, Lowercase("abc-1"))
+	assert.False(t, Lowercase("ǅ"))
+	assert.True(t, Uppercase("ABC-1"))
+	assert.False(t, NoWhitespace("a b"))
+}
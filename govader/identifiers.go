@@ -0,0 +1,186 @@
+package govader
+
+import (
+	"bufio"
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	//go:embed codes/iso3166.txt
+	iso3166 string
+
+	//go:embed codes/iso4217.txt
+	iso4217 string
+
+	//go:embed codes/iban.txt
+	ibanLengths string
+)
+
+// codeTable holds the embedded code tables keyed by code.
+type codeTable struct {
+	alpha2, alpha3, currencies map[string]bool
+	iban                       map[string]int // IBAN length by country code.
+}
+
+// codeTables loads the code tables on first use.
+var codeTables = sync.OnceValue(func() codeTable {
+	t := codeTable{alpha2: map[string]bool{}, alpha3: map[string]bool{}, currencies: map[string]bool{}, iban: map[string]int{}}
+	for _, fields := range readTable(iso3166) {
+		t.alpha2[fields[0]], t.alpha3[fields[1]] = true, true
+	}
+	for _, fields := range readTable(iso4217) {
+		t.currencies[fields[0]] = true
+	}
+	for _, fields := range readTable(ibanLengths) {
+		t.iban[fields[0]], _ = strconv.Atoi(fields[1])
+	}
+	return t
+})
+
+// readTable returns the space separated fields of the lines of a code
+// table, skipping comments.
+func readTable(table string) (rows [][]string) {
+	sc := bufio.NewScanner(strings.NewReader(table))
+	for sc.Scan() {
+		if line := sc.Text(); line != "" && !strings.HasPrefix(line, "#") {
+			rows = append(rows, strings.Fields(line))
+		}
+	}
+	return rows
+}
+
+// digits returns the digits of s ignoring spaces and dashes, e.g. of a
+// card number written 4111-1111-1111-1111. It returns false if s contains
+// any other character.
+func digits(s string) ([]int, bool) {
+	var d []int
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			d = append(d, int(r-'0'))
+		case r != ' ' && r != '-':
+			return nil, false
+		}
+	}
+	return d, true
+}
+
+// Luhn reports whether s is a number passing the Luhn checksum, such as
+// a credit card number. Spaces and dashes are ignored.
+func Luhn(s string) bool {
+	d, ok := digits(s)
+	if !ok || len(d) < 2 {
+		return false
+	}
+	sum := 0
+	for i := range d {
+		n := d[len(d)-1-i]
+		if i%2 == 1 {
+			if n *= 2; n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// IBAN reports whether s is an International Bank Account Number with the
+// length of its country and a valid mod 97 checksum. Spaces are ignored,
+// e.g. GB82 WEST 1234 5698 7654 32.
+func IBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 4 || codeTables().iban[s[:2]] != len(s) {
+		return false
+	}
+	rem := 0
+	for _, r := range s[4:] + s[:4] { // The check digits move to the end.
+		switch {
+		case r >= '0' && r <= '9':
+			rem = (rem*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			rem = (rem*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
+
+// ISBN10 reports whether s is a 10 digit International Standard Book
+// Number, the check digit may be X. Spaces and dashes are ignored.
+func ISBN10(s string) bool {
+	x := strings.HasSuffix(s, "X")
+	d, ok := digits(strings.TrimSuffix(s, "X"))
+	if x {
+		d = append(d, 10)
+	}
+	if !ok || len(d) != 10 {
+		return false
+	}
+	sum := 0
+	for i, n := range d {
+		sum += (10 - i) * n
+	}
+	return sum%11 == 0
+}
+
+// ISBN13 reports whether s is a 13 digit International Standard Book
+// Number starting with 978 or 979. Spaces and dashes are ignored.
+func ISBN13(s string) bool {
+	d, ok := digits(s)
+	if !ok || len(d) != 13 || d[0] != 9 || d[1] != 7 || d[2] != 8 && d[2] != 9 {
+		return false
+	}
+	sum := 0
+	for i, n := range d {
+		sum += n * (1 + 2*(i%2))
+	}
+	return sum%10 == 0
+}
+
+// E164 reports whether s is a phone number in E.164 format, a plus sign
+// followed by at most 15 digits without a leading zero, e.g. +14155552671.
+func E164(s string) bool {
+	if len(s) < 3 || len(s) > 16 || s[0] != '+' || s[1] == '0' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ISO3166Alpha2 reports whether s is an ISO 3166-1 alpha-2 country code,
+// e.g. DE.
+func ISO3166Alpha2(s string) bool {
+	return codeTables().alpha2[s]
+}
+
+// ISO3166Alpha3 reports whether s is an ISO 3166-1 alpha-3 country code,
+// e.g. DEU.
+func ISO3166Alpha3(s string) bool {
+	return codeTables().alpha3[s]
+}
+
+// ISO4217 reports whether s is an ISO 4217 currency code, e.g. EUR.
+func ISO4217(s string) bool {
+	return codeTables().currencies[s]
+}
+
+// BCP47 reports whether s is a well-formed BCP 47 language tag of known
+// subtags, e.g. en-US or zh-Hant-TW.
+func BCP47(s string) bool {
+	if strings.Contains(s, "_") { // Accepted by language.Parse.
+		return false
+	}
+	_, err := language.Parse(s)
+	return err == nil
+}
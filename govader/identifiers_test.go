@@ -0,0 +1,28 @@
+package govader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__Identifiers(t *testing.T) {
+	assert.True(t, Luhn("79927398713"))
+	assert.False(t, Luhn("79927398710"))
+	assert.False(t, Luhn("7992739871a"))
+	assert.True(t, IBAN("DE89370400440532013000"))
+	assert.False(t, IBAN("DE8937040044053201300")) // Too short for DE.
+	assert.False(t, IBAN("ZZ89370400440532013000"))
+	assert.True(t, ISBN10("080442957X"))
+	assert.False(t, ISBN10("0804429579"))
+	assert.True(t, ISBN13("9780306406157"))
+	assert.False(t, E164("+1 415 555 2671"))
+	assert.False(t, E164("+1234567890123456"))
+	assert.True(t, ISO3166Alpha2("AX"))
+	assert.False(t, ISO3166Alpha2("ax"))
+	assert.True(t, ISO3166Alpha3("ALA"))
+	assert.True(t, ISO4217("XAU"))
+	assert.False(t, ISO4217("DEM")) // Withdrawn.
+	assert.True(t, BCP47("de-CH-1996"))
+	assert.False(t, BCP47("xx"))
+}
@@ -0,0 +1,84 @@
+package govader
+
+import (
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// IPAddr returns ip as a netip.Addr, with IPv4-mapped IPv6 addresses
+// unmapped. It returns the zero Addr if ip is not a valid IP address.
+func IPAddr(ip net.IP) netip.Addr {
+	a, _ := netip.AddrFromSlice(ip)
+	return a.Unmap()
+}
+
+// InPrefix reports whether the prefix of a with the given number of bits
+// lies within one of prefixes, e.g. 10.1.0.0/16 lies within 10.0.0.0/8.
+// An address is the prefix of its full bit length.
+func InPrefix(a netip.Addr, bits int, prefixes ...string) bool {
+	for _, s := range prefixes {
+		if p := netip.MustParsePrefix(s); p.Bits() <= bits && p.Contains(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// IP reports whether s is an IPv4 or IPv6 address.
+func IP(s string) bool {
+	_, err := netip.ParseAddr(s)
+	return err == nil
+}
+
+// IPv4 reports whether s is an IPv4 address.
+func IPv4(s string) bool {
+	a, err := netip.ParseAddr(s)
+	return err == nil && a.Is4()
+}
+
+// IPv6 reports whether s is an IPv6 address.
+func IPv6(s string) bool {
+	a, err := netip.ParseAddr(s)
+	return err == nil && a.Is6()
+}
+
+// CIDR reports whether s is an IP prefix in CIDR notation, e.g. 10.0.0.0/8.
+func CIDR(s string) bool {
+	_, err := netip.ParsePrefix(s)
+	return err == nil
+}
+
+// MAC reports whether s is an IEEE 802 MAC address.
+func MAC(s string) bool {
+	_, err := net.ParseMAC(s)
+	return err == nil
+}
+
+// Port reports whether s is a port number from 1 to 65535.
+func Port(s string) bool {
+	port, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && port != 0
+}
+
+// Hostname reports whether s is a RFC 1123 hostname.
+func Hostname(s string) bool {
+	ok := len(s) > 0 && len(s) <= 253
+	for _, label := range strings.Split(s, ".") {
+		ok = ok && len(label) > 0 && len(label) <= 63 && label[0] != '-' && label[len(label)-1] != '-'
+		for i := 0; ok && i < len(label); i++ {
+			c := label[i]
+			ok = c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+		}
+	}
+	return ok
+}
+
+// FQDN reports whether s is a hostname of at least two labels with an
+// optional trailing dot and a non-numeric top-level domain.
+func FQDN(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	tld := s[strings.LastIndex(s, ".")+1:]
+	return Hostname(s) && strings.Contains(s, ".") && strings.Trim(tld, "0123456789") != ""
+}
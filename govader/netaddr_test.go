@@ -0,0 +1,38 @@
+package govader
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__InPrefix(t *testing.T) {
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), IPAddr(net.ParseIP("10.0.0.1")))
+	assert.False(t, IPAddr(net.IP{1, 2}).IsValid())
+	assert.True(t, InPrefix(netip.MustParseAddr("10.1.2.3"), 32, "192.168.0.0/16", "10.0.0.0/8"))
+	assert.True(t, InPrefix(netip.MustParseAddr("10.1.0.0"), 16, "10.0.0.0/8"))
+	assert.False(t, InPrefix(netip.MustParseAddr("10.0.0.0"), 7, "10.0.0.0/8"))
+}
+
+func Test__NetFormats(t *testing.T) {
+	assert.True(t, IP("::1"))
+	assert.False(t, IP("256.0.0.1"))
+	assert.True(t, IPv4("10.0.0.1"))
+	assert.False(t, IPv4("::1"))
+	assert.True(t, IPv6("::ffff:10.0.0.1"))
+	assert.False(t, IPv6("10.0.0.1"))
+	assert.True(t, CIDR("10.0.0.0/8"))
+	assert.False(t, CIDR("10.0.0.0/33"))
+	assert.True(t, MAC("00:1a:2b:3c:4d:5e"))
+	assert.False(t, MAC("00:1a:2b"))
+	assert.True(t, Port("65535"))
+	assert.False(t, Port("0"))
+	assert.True(t, Hostname("localhost"))
+	assert.False(t, Hostname("-a.example.com"))
+	assert.False(t, Hostname("a..example.com"))
+	assert.True(t, FQDN("example.com."))
+	assert.False(t, FQDN("localhost"))
+	assert.False(t, FQDN("10.0.0.1"))
+}
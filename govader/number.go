@@ -0,0 +1,79 @@
+package govader
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// Decimal is implemented by arbitrary-precision decimal types, e.g. money
+// types, which rules compare exactly with their tag constants. String must
+// return the value as a decimal number such as "-12.50".
+type Decimal[T any] interface {
+	Cmp(T) int
+	String() string
+}
+
+// Rat returns the exact value of v, which is a *big.Int, *big.Rat,
+// *big.Float or Decimal. It returns false if v is a nil pointer, an
+// infinite *big.Float or a Decimal whose String is not a number.
+func Rat(v fmt.Stringer) (*big.Rat, bool) {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, false
+	}
+	switch x := v.(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(x), true
+	case *big.Rat:
+		return x, true
+	case *big.Float:
+		if x.IsInf() {
+			return nil, false
+		}
+		r, _ := x.Rat(nil)
+		return r, true
+	default:
+		return new(big.Rat).SetString(v.String())
+	}
+}
+
+// Compare reports whether v op c holds, where op is one of ==, !=, <, <=,
+// > and >= and c is a number constant such as "10.50" or "1/3". Nil
+// numbers satisfy every comparison.
+func Compare(v fmt.Stringer, op, c string) bool {
+	r, ok := Rat(v)
+	if !ok {
+		return true
+	}
+	k, ok := new(big.Rat).SetString(c)
+	if !ok {
+		panic(fmt.Sprintf("govader: invalid number constant %q", c))
+	}
+	switch n := r.Cmp(k); op {
+	case "==":
+		return n == 0
+	case "!=":
+		return n != 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	default:
+		panic(fmt.Sprintf("govader: invalid comparison operator %q", op))
+	}
+}
+
+// DecimalPlaces reports whether v has at most n digits after the decimal
+// point, e.g. 12.50 has two and 1/3 has no finite number of places.
+func DecimalPlaces(v fmt.Stringer, n int) bool {
+	r, ok := Rat(v)
+	if !ok {
+		return true
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	return new(big.Rat).Mul(r, new(big.Rat).SetInt(scale)).IsInt()
+}
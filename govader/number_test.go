@@ -0,0 +1,21 @@
+package govader
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__Compare(t *testing.T) {
+	third := big.NewRat(1, 3)
+	assert.True(t, Compare(third, "<", "0.3334"))
+	assert.False(t, Compare(third, ">=", "0.3334"))
+	assert.True(t, Compare(third, "==", "1/3"))
+	assert.True(t, Compare(big.NewInt(-1), "<", "0"))
+	assert.True(t, Compare(big.NewFloat(0.1), ">", "0.1")) // The float is not exactly 0.1.
+	assert.True(t, Compare((*big.Int)(nil), ">", "0"))
+	assert.True(t, DecimalPlaces(big.NewRat(1, 8), 3))
+	assert.False(t, DecimalPlaces(big.NewRat(1, 8), 2))
+	assert.False(t, DecimalPlaces(third, 100))
+}
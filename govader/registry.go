@@ -0,0 +1,136 @@
+// Package govader is the runtime companion of the govader code generator.
+//
+// Generated schemas register themselves into a package-level registry in
+// their init function, which lets callers validate values whose concrete
+// type is only known at runtime, e.g. in a generic HTTP decoder:
+//
+//	if err := govader.Validate(v); err != nil {
+//		...
+//	}
+package govader
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Validatable is implemented by every schema generated by govader.
+type Validatable interface {
+	Validate() []string
+}
+
+// GroupValidatable is implemented by schemas supporting validation groups.
+type GroupValidatable interface {
+	Validatable
+	ValidateGroups(groups ...string) []string
+}
+
+// FieldValidatable is implemented by schemas supporting partial validation.
+type FieldValidatable interface {
+	Validatable
+	ValidateFields(paths ...string) []string
+}
+
+// ErrNoSchema is returned when no schema is registered for a type.
+var ErrNoSchema = errors.New("govader: no schema registered")
+
+// Errors holds the validation messages reported by a schema.
+type Errors []string
+
+func (e Errors) Error() string {
+	return strings.Join(e, "\n")
+}
+
+var registry = struct {
+	sync.RWMutex
+	schemas map[reflect.Type]func(v any) Validatable
+}{
+	schemas: make(map[reflect.Type]func(v any) Validatable),
+}
+
+// Register registers the schema constructor of type T. It is called from
+// the init function of generated code and panics if T is registered twice.
+func Register[T any](fn func(v T) Validatable) {
+	typ := reflect.TypeFor[T]()
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.schemas[typ]; ok {
+		panic(fmt.Sprintf("govader: schema for %v registered twice", typ))
+	}
+	registry.schemas[typ] = func(v any) Validatable {
+		return fn(v.(T))
+	}
+}
+
+// Lookup returns the schema of v, dereferencing pointers.
+// It reports false if no schema is registered for the type of v.
+func Lookup(v any) (Validatable, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, false
+	}
+	registry.RLock()
+	fn, ok := registry.schemas[rv.Type()]
+	registry.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return fn(rv.Interface()), true
+}
+
+// Validate validates v against its registered schema. It returns Errors
+// if any rule fails, or an error wrapping ErrNoSchema if the type of v
+// has no schema.
+func Validate(v any) error {
+	schema, ok := Lookup(v)
+	if !ok {
+		return fmt.Errorf("%w for %T", ErrNoSchema, v)
+	}
+	if messages := schema.Validate(); len(messages) > 0 {
+		return Errors(messages)
+	}
+	return nil
+}
+
+// ValidateGroups validates v against its registered schema, running rules
+// without groups and rules of the given groups.
+func ValidateGroups(v any, groups ...string) error {
+	schema, ok := Lookup(v)
+	if !ok {
+		return fmt.Errorf("%w for %T", ErrNoSchema, v)
+	}
+	gs, ok := schema.(GroupValidatable)
+	if !ok {
+		return fmt.Errorf("govader: schema for %T does not support groups", v)
+	}
+	if messages := gs.ValidateGroups(groups...); len(messages) > 0 {
+		return Errors(messages)
+	}
+	return nil
+}
+
+// ValidateFields validates only the given field paths of v against its
+// registered schema, e.g. the fields sent in a PATCH request.
+func ValidateFields(v any, paths ...string) error {
+	schema, ok := Lookup(v)
+	if !ok {
+		return fmt.Errorf("%w for %T", ErrNoSchema, v)
+	}
+	fs, ok := schema.(FieldValidatable)
+	if !ok {
+		return fmt.Errorf("govader: schema for %T does not support field paths", v)
+	}
+	if messages := fs.ValidateFields(paths...); len(messages) > 0 {
+		return Errors(messages)
+	}
+	return nil
+}
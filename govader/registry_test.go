@@ -0,0 +1,58 @@
+package govader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	Name string
+}
+
+type testUserSchema struct {
+	u testUser
+}
+
+func (s testUserSchema) Validate() (messages []string) {
+	if s.u.Name == "" {
+		messages = append(messages, "The Name field is required.")
+	}
+	return messages
+}
+
+func Test__Validate(t *testing.T) {
+	Register(func(u testUser) Validatable { return testUserSchema{u} })
+
+	assert.NoError(t, Validate(testUser{Name: "Jane"}))
+	assert.NoError(t, Validate(&testUser{Name: "Jane"}))
+
+	err := Validate(testUser{})
+	assert.Equal(t, Errors{"The Name field is required."}, err)
+
+	err = Validate(struct{}{})
+	assert.True(t, errors.Is(err, ErrNoSchema))
+	assert.True(t, errors.Is(Validate((*testUser)(nil)), ErrNoSchema))
+
+	err = ValidateGroups(testUser{}, "create")
+	assert.EqualError(t, err, "govader: schema for govader.testUser does not support groups")
+
+	err = ValidateContext(context.Background(), testUser{})
+	assert.EqualError(t, err, "govader: schema for govader.testUser does not support context")
+
+	assert.Panics(t, func() {
+		Register(func(u testUser) Validatable { return testUserSchema{u} })
+	})
+}
+
+func Test__Reporter(t *testing.T) {
+	var r Reporter
+	r.Report("Total", "sum", "The Total field must equal the sum of items.")
+	r.Report("Note", "required", "")
+	assert.Equal(t, []FieldError{
+		{Field: "Total", Code: "sum", Message: "The Total field must equal the sum of items."},
+		{Field: "Note", Code: "required"},
+	}, r.Errors())
+}
@@ -0,0 +1,33 @@
+package govader
+
+// FieldError is an error reported against a field path.
+type FieldError struct {
+	Field   string // Path of the field, e.g. Total or Items[1].Price.
+	Code    string // Code of the error, used as locale message key if Message is empty.
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// Reporter collects the errors reported by a struct-level validation hook,
+// a method of the validated type with the signature:
+//
+//	func (u User) ValidateStruct(r *govader.Reporter)
+//
+// Generated schemas call the hook after the tag rules and add the reported
+// errors to the same messages.
+type Reporter struct {
+	errors []FieldError
+}
+
+// Report reports an error with a code and message against a field path.
+func (r *Reporter) Report(field, code, message string) {
+	r.errors = append(r.errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Errors returns the reported errors.
+func (r *Reporter) Errors() []FieldError {
+	return r.errors
+}
@@ -0,0 +1,90 @@
+package govader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NFC returns s in Unicode normalization form C, e.g. a decomposed
+// "e" and combining acute accent become "é".
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NFKC returns s in Unicode normalization form KC, which also folds
+// compatibility characters such as full-width digits into their ASCII form.
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// CollapseSpaces replaces each run of white space in s with a single space.
+func CollapseSpaces(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// StripControl removes the control characters from s, including tabs and
+// line breaks.
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+var normalizers = struct {
+	sync.RWMutex
+	funcs map[reflect.Type]func(v any)
+}{
+	funcs: make(map[reflect.Type]func(v any)),
+}
+
+// RegisterNormalizer registers the function applying the defaults and
+// sanitizers of type T. It is called from the init function of generated code and panics
+// if T is registered twice.
+func RegisterNormalizer[T any](fn func(v *T)) {
+	typ := reflect.TypeFor[T]()
+	normalizers.Lock()
+	defer normalizers.Unlock()
+	if _, ok := normalizers.funcs[typ]; ok {
+		panic(fmt.Sprintf("govader: normalizer for %v registered twice", typ))
+	}
+	normalizers.funcs[typ] = func(v any) {
+		fn(v.(*T))
+	}
+}
+
+// ValidateAndNormalize applies the defaults and sanitizers of the type v
+// points to in place and validates the normalized value, see Validate.
+func ValidateAndNormalize(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("govader: ValidateAndNormalize needs a non-nil pointer, got %T", v)
+	}
+	normalizers.RLock()
+	fn, ok := normalizers.funcs[rv.Type().Elem()]
+	normalizers.RUnlock()
+	if ok {
+		fn(v)
+	}
+	return Validate(v)
+}
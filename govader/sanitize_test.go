@@ -0,0 +1,30 @@
+package govader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__Sanitizers(t *testing.T) {
+	assert.Equal(t, "é", NFC("e\u0301"))
+	assert.Equal(t, "0300", NFKC("０３００"))
+	assert.Equal(t, " Jane Doe ", CollapseSpaces(" \tJane \n Doe  "))
+	assert.Equal(t, "ab", StripControl("a\x00\nb"))
+}
+
+func Test__ValidateAndNormalize(t *testing.T) {
+	type testPost struct {
+		Title string
+	}
+	Register(func(p testPost) Validatable {
+		return testUserSchema{testUser{Name: p.Title}}
+	})
+	RegisterNormalizer(func(p *testPost) { p.Title = CollapseSpaces(p.Title) })
+
+	p := testPost{Title: "a  b"}
+	assert.NoError(t, ValidateAndNormalize(&p))
+	assert.Equal(t, "a b", p.Title)
+	assert.EqualError(t, ValidateAndNormalize(p), "govader: ValidateAndNormalize needs a non-nil pointer, got govader.testPost")
+	assert.Panics(t, func() { RegisterNormalizer(func(p *testPost) {}) })
+}